@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/framework/driver/db"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/interface_adapter/gateway"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
+)
+
+const grantAdminUsage = `usage: server grant-admin <email>
+
+gives the admin role to the registered user with this email. Only admins can
+assign roles through the API, so this is how the first admin is made.`
+
+// runGrantAdmin handles the grant-admin subcommand and returns the process exit code.
+func runGrantAdmin(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, grantAdminUsage)
+		return 2
+	}
+
+	if err := grantAdmin(args[0]); err != nil {
+		fmt.Fprintln(os.Stderr, "grant-admin:", err)
+		return 1
+	}
+	return 0
+}
+
+// grantAdmin gives the admin role to the user with the email.
+func grantAdmin(email string) error {
+	database, err := db.ConnectDB(config.LoadDBConfig())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	// The schema and roles may not exist yet if the API has never started
+	migrator, err := db.NewMigrator(database)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if _, err := migrator.Up(ctx); err != nil {
+		return err
+	}
+	if err := db.SeedRoles(database); err != nil {
+		return err
+	}
+
+	user, err := gateway.NewUserRepository(database).FindByEmail(ctx, email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return fmt.Errorf("no user is registered with %s", email)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := gateway.NewRoleRepository(database).AssignToUser(ctx, user.ID, entity.RoleAdmin); err != nil {
+		return err
+	}
+
+	fmt.Printf("Granted %s to %s (%s)\n", entity.RoleAdmin, user.Username, user.ID)
+	return nil
+}
@@ -1,14 +1,23 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"os"
+	"time"
+	_ "time/tzdata" // embed the IANA database so event time zones resolve in minimal images
 
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/framework/driver/db"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/framework/notification"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/interface_adapter/controller"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/interface_adapter/gateway"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/interface_adapter/routes"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/service"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/auth"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/middlewares"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -18,58 +27,166 @@ import (
 func init() {
 	// Load .env file (it will look for .env in the root directory)
 	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: .env file not found. Using environment variables.")
+		slog.Warn(".env file not found, using environment variables")
 	}
 }
 
 func main() {
+	// Route all logging through the structured logger so sensitive fields are redacted
+	logger.Setup(os.Stdout, config.LoadLogConfig())
+
+	// `server migrate ...` manages the schema instead of starting the API
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
+	// `server grant-admin <email>` makes the first administrator
+	if len(os.Args) > 1 && os.Args[1] == "grant-admin" {
+		os.Exit(runGrantAdmin(os.Args[2:]))
+	}
+
 	// Load the database configuration from environment variables or .env
 	dbConfig := config.LoadDBConfig()
 
-	// Debug: Print the loaded database configuration
-	log.Printf("DB Config: Host=%s, Port=%s, User=%s, Password=%s, DBName=%s, SSLMode=%s",
-		dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.SSLMode)
+	// Print the loaded database configuration, without the password
+	slog.Info("Database configuration", "host", dbConfig.Host, "port", dbConfig.Port, "user", dbConfig.User, "db_name", dbConfig.DBName, "ssl_mode", dbConfig.SSLMode)
 
 	// Connect to the database
 	database, err := db.ConnectDB(dbConfig)
 	if err != nil {
-		log.Fatal("Error connecting to the database:", err)
+		slog.Error("Error connecting to the database", "error", err)
+		os.Exit(1)
 	}
 	defer database.Close()
 
-	// Create the required tables if they don't exist
-	if err := db.CreateTables(database); err != nil {
-		log.Fatal("Error creating tables:", err)
+	// Bring the schema up to date. Concurrent instances wait on the migration lock.
+	migrator, err := db.NewMigrator(database)
+	if err != nil {
+		slog.Error("Error loading migrations", "error", err)
+		os.Exit(1)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		slog.Error("Error applying migrations", "error", err)
+		os.Exit(1)
+	}
+
+	// Seed the built-in roles and permissions
+	if err := db.SeedRoles(database); err != nil {
+		slog.Error("Error seeding roles", "error", err)
+		os.Exit(1)
+	}
 
+	// Load the token signing keys
+	authConfig := config.LoadAuthConfig()
+	tokenManager, err := auth.NewTokenManager(authConfig)
+	if err != nil {
+		slog.Error("Error loading token signing keys", "error", err)
+		os.Exit(1)
 	}
 
+	// Tokens are stored as keyed hashes, so the key must be stable across restarts
+	if authConfig.TokenHashKey == "" {
+		slog.Error("TOKEN_HASH_KEY must be set")
+		os.Exit(1)
+	}
+	tokenHashKey := []byte(authConfig.TokenHashKey)
+
 	// Initialize the repositories
 	userRepository := gateway.NewUserRepository(database)
-	tokenRepository := gateway.NewTokenRepository(database)
+	tokenRepository := gateway.NewTokenRepository(database, tokenHashKey)
 	eventRepository := gateway.NewEventRepository(database)
+	registrationRepository := gateway.NewRegistrationRepository(database)
+	roleRepository := gateway.NewRoleRepository(database)
+	permissionRepository := gateway.NewPermissionRepository(database)
+	twoFactorRepository := gateway.NewTwoFactorRepository(database, tokenHashKey)
+	auditRepository := gateway.NewAuditRepository(database)
+	unitOfWork := gateway.NewUnitOfWork(database)
+
+	// Sessions are re-checked for revocation at most once a minute per instance
+	revocations := auth.NewRevocationList(tokenRepository, time.Minute, authConfig.AccessTokenTTL)
+
+	// Initialize the mailer
+	mailerConfig := config.LoadMailerConfig()
+	var mailer service.Mailer
+	switch mailerConfig.Driver {
+	case "smtp":
+		mailer = notification.NewSMTPMailer(mailerConfig)
+	case "memory":
+		mailer = notification.NewMemoryMailer()
+	default:
+		mailer = notification.NewFileMailer(mailerConfig.FilePath)
+	}
+
+	// Initialize the notifier
+	notifierConfig := config.LoadNotifierConfig()
+	var notifier service.Notifier
+	switch notifierConfig.Driver {
+	case "email":
+		notifier = notification.NewEmailNotifier(userRepository, mailer)
+	case "file":
+		notifier = notification.NewFileNotifier(notifierConfig.FilePath)
+	default:
+		notifier = notification.NewLogNotifier()
+	}
+
+	// Initialize brute-force protection
+	lockoutConfig := config.LoadLockoutConfig()
+	var loginAttemptRepository repository.LoginAttemptRepository
+	switch lockoutConfig.Store {
+	case "memory":
+		loginAttemptRepository = gateway.NewMemoryLoginAttemptRepository()
+	default:
+		loginAttemptRepository = gateway.NewLoginAttemptRepository(database)
+	}
+	loginLimiter := service.NewLoginLimiter(loginAttemptRepository, auditRepository, lockoutConfig)
 
 	// Initialize the services
-	userService := service.NewUserService(userRepository, tokenRepository)
-	eventService := service.NewEventService(eventRepository, tokenRepository)
+	userService := service.NewUserService(userRepository, tokenRepository, roleRepository, twoFactorRepository, tokenManager, revocations, notifier, mailer, loginLimiter, unitOfWork, authConfig)
+	eventService := service.NewEventService(eventRepository, registrationRepository, tokenRepository, permissionRepository, notifier, unitOfWork)
 	// Initialize the controllers
 	userController := controller.NewUserController(userService)
 	eventController := controller.NewEventController(eventService)
 
-	r := gin.Default()
+	// Permanently remove deleted records once they are past the retention window
+	purgeJob := service.NewPurgeJob(userRepository, eventRepository, tokenRepository, config.LoadPurgeConfig())
+	go purgeJob.Run(context.Background())
+
+	serverConfig := config.LoadServerConfig()
+
+	// The request DTOs rely on custom validation rules
+	if err := controller.RegisterValidations(); err != nil {
+		slog.Error("Error registering validations", "error", err)
+		os.Exit(1)
+	}
+
+	// Request logging replaces gin's default text logger
+	r := gin.New()
+
+	// Sign-in lockouts are keyed on the client IP, so forwarding headers are
+	// only believed from known proxies; otherwise any client could pick its IP
+	if err := r.SetTrustedProxies(serverConfig.TrustedProxies); err != nil {
+		slog.Error("Invalid TRUSTED_PROXIES", "error", err)
+		os.Exit(1)
+	}
+
+	r.Use(middlewares.LoggingMiddleware(), gin.Recovery(), middlewares.ErrorMiddleware(), middlewares.TimeoutMiddleware(serverConfig.RequestTimeout))
+
 	// Apply CORS middleware
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "https://your-frontend-domain.com"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", middlewares.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", middlewares.RequestIDHeader},
 		AllowCredentials: true,
 	}))
 
-	routes.RegisterUserRoutes(r, userController, tokenRepository)
-	routes.RegistereventsRoutes(r, eventController, tokenRepository)
+	routes.RegisterUserRoutes(r, userController, tokenManager, revocations, permissionRepository)
+	routes.RegisterAuthRoutes(r, userController, tokenManager, revocations)
+	routes.RegistereventsRoutes(r, eventController, tokenManager, revocations, permissionRepository)
 
 	// Start the server
 	if err := r.Run(":8080"); err != nil {
-		log.Fatal("Error starting the server:", err)
+		slog.Error("Error starting the server", "error", err)
+		os.Exit(1)
 	}
 }
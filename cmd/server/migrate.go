@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/framework/driver/db"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up            apply all pending migrations
+  down [N]      roll back the last N applied migrations (default 1)
+  status        list migrations and whether they have been applied
+  create NAME   add an empty up/down migration pair named NAME
+  hash-tokens   hash the tokens stored in plaintext by earlier versions,
+                once, after up; needs TOKEN_HASH_KEY`
+
+// runMigrate handles the migrate subcommand and returns the process exit code.
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	if err := migrate(args[0], args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		return 1
+	}
+	return 0
+}
+
+// migrate runs one migrate command.
+func migrate(command string, args []string) error {
+	// Creating files does not need a database connection
+	if command == "create" {
+		if len(args) != 1 {
+			return errors.New("create needs a migration name")
+		}
+
+		upPath, downPath, err := db.CreateMigration(db.MigrationsDir, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println("Created", upPath)
+		fmt.Println("Created", downPath)
+		return nil
+	}
+
+	database, err := db.ConnectDB(config.LoadDBConfig())
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	migrator, err := db.NewMigrator(database)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("Applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			steps, err = strconv.Atoi(args[0])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid step count %q", args[0])
+			}
+		}
+
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, migration := range rolledBack {
+			fmt.Printf("Rolled back %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			fmt.Println("No applied migrations")
+		}
+		return nil
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			state := "pending"
+			switch {
+			case status.Missing:
+				state = "applied " + status.AppliedAt.Format(time.RFC3339) + " (file missing)"
+			case status.AppliedAt != nil:
+				state = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-40s %s\n", status.Version, status.Name, state)
+		}
+		return nil
+
+	case "hash-tokens":
+		// Tokens must be hashed with the key the API will look them up with
+		authConfig := config.LoadAuthConfig()
+		if authConfig.TokenHashKey == "" {
+			return errors.New("TOKEN_HASH_KEY must be set")
+		}
+
+		hashed, err := migrator.HashStoredTokens(ctx, []byte(authConfig.TokenHashKey))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Hashed %d plaintext tokens\n", hashed)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", command, migrateUsage)
+	}
+}
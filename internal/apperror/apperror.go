@@ -0,0 +1,182 @@
+// Package apperror defines the domain errors returned by services and
+// repositories. Each error carries a Kind that says what went wrong, so the
+// HTTP layer can pick a status code without knowing which layer failed.
+package apperror
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Kind classifies a domain error.
+type Kind int
+
+const (
+	// Internal is any error that is not a domain error, such as a lost database connection
+	Internal Kind = iota
+
+	// NotFound means the requested resource does not exist
+	NotFound
+
+	// Conflict means the request clashes with the current state of a resource
+	Conflict
+
+	// Validation means the request is well formed but breaks a business rule
+	Validation
+
+	// Forbidden means the caller is known but not allowed to do this
+	Forbidden
+
+	// Unauthorized means the caller could not be authenticated
+	Unauthorized
+
+	// TooManyRequests means the caller must wait before trying again
+	TooManyRequests
+
+	// BadRequest means the request cannot be understood, such as a body that
+	// is not JSON or a malformed ID
+	BadRequest
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case NotFound:
+		return "not_found"
+	case Conflict:
+		return "conflict"
+	case Validation:
+		return "validation"
+	case Forbidden:
+		return "forbidden"
+	case Unauthorized:
+		return "unauthorized"
+	case TooManyRequests:
+		return "too_many_requests"
+	case BadRequest:
+		return "bad_request"
+	default:
+		return "internal"
+	}
+}
+
+// Error is a domain error. Its message is safe to show to clients.
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+	// Params fill the {0}, {1}… placeholders of Message, which stays fixed
+	// so it can be translated
+	Params []string
+	// Fields lists the invalid fields of a Validation error, if known
+	Fields []FieldError
+}
+
+// FieldError describes one invalid field of a request. Code is stable and
+// meant for programs; Message is meant for people and is rendered from Code
+// and Params in the caller's language when the response is written.
+type FieldError struct {
+	Field   string   `json:"field"`
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Params  []string `json:"-"`
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	for i, param := range e.Params {
+		message = strings.ReplaceAll(message, "{"+strconv.Itoa(i)+"}", param)
+	}
+
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New creates a domain error of the given kind.
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap creates a domain error of the given kind that wraps err.
+func Wrap(kind Kind, message string, err error) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// WithParams returns a copy of e whose message placeholders are filled from
+// params.
+func (e *Error) WithParams(params ...string) *Error {
+	withParams := *e
+	withParams.Params = params
+	return &withParams
+}
+
+// NewNotFound creates a NotFound error.
+func NewNotFound(message string) *Error {
+	return New(NotFound, message)
+}
+
+// NewConflict creates a Conflict error.
+func NewConflict(message string) *Error {
+	return New(Conflict, message)
+}
+
+// NewValidation creates a Validation error.
+func NewValidation(message string) *Error {
+	return New(Validation, message)
+}
+
+// NewInvalidFields creates a Validation error listing the invalid fields.
+func NewInvalidFields(fields []FieldError) *Error {
+	return &Error{Kind: Validation, Message: "request has invalid fields", Fields: fields}
+}
+
+// NewBadRequest creates a BadRequest error.
+func NewBadRequest(message string) *Error {
+	return New(BadRequest, message)
+}
+
+// NewForbidden creates a Forbidden error.
+func NewForbidden(message string) *Error {
+	return New(Forbidden, message)
+}
+
+// NewUnauthorized creates an Unauthorized error.
+func NewUnauthorized(message string) *Error {
+	return New(Unauthorized, message)
+}
+
+// NewTooManyRequests creates a TooManyRequests error.
+func NewTooManyRequests(message string) *Error {
+	return New(TooManyRequests, message)
+}
+
+// KindOf returns the kind of the first domain error in err's chain, or
+// Internal when there is none.
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return Internal
+}
+
+// FieldsOf returns the invalid fields of the first domain error in err's chain.
+func FieldsOf(err error) []FieldError {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Fields
+	}
+	return nil
+}
+
+// Is reports whether err's chain holds a domain error of the given kind.
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
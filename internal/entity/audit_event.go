@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Audit event types
+const (
+	AuditAccountLocked   = "account_locked"
+	AuditIPLocked        = "ip_locked"
+	AuditAccountUnlocked = "account_unlocked"
+)
+
+// AuditEvent records a security-relevant action.
+// UserID is the affected user and ActorID the user who acted, when known.
+type AuditEvent struct {
+	ID        uuid.UUID  `json:"id"`
+	Type      string     `json:"type"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	ActorID   *uuid.UUID `json:"actor_id,omitempty"`
+	IPAddress string     `json:"ip_address"`
+	Detail    string     `json:"detail"`
+	CreatedAt time.Time  `json:"created_at"`
+}
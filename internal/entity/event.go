@@ -13,6 +13,7 @@ type Event struct {
 	Location    string     `json:"location"`
 	StartTime   time.Time  `json:"starttime"`
 	EndTime     time.Time  `json:"endtime"`
+	TimeZone    string     `json:"timezone"`
 	Capacity    int        `json:"capacity"`
 	IsPublic    bool       `json:"ispublic"`
 	Status      string     `json:"status"`
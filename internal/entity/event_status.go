@@ -0,0 +1,41 @@
+package entity
+
+// Event lifecycle statuses
+const (
+	EventStatusDraft              = "draft"
+	EventStatusPublished          = "published"
+	EventStatusRegistrationOpen   = "registration_open"
+	EventStatusRegistrationClosed = "registration_closed"
+	EventStatusOngoing            = "ongoing"
+	EventStatusCompleted          = "completed"
+	EventStatusCancelled          = "cancelled"
+	EventStatusArchived           = "archived"
+)
+
+// eventStatusTransitions lists, for each status, the statuses it may move to
+var eventStatusTransitions = map[string][]string{
+	EventStatusDraft:              {EventStatusPublished, EventStatusCancelled},
+	EventStatusPublished:          {EventStatusDraft, EventStatusRegistrationOpen, EventStatusCancelled},
+	EventStatusRegistrationOpen:   {EventStatusRegistrationClosed, EventStatusCancelled},
+	EventStatusRegistrationClosed: {EventStatusRegistrationOpen, EventStatusOngoing, EventStatusCancelled},
+	EventStatusOngoing:            {EventStatusCompleted, EventStatusCancelled},
+	EventStatusCompleted:          {EventStatusArchived},
+	EventStatusCancelled:          {EventStatusArchived},
+	EventStatusArchived:           {},
+}
+
+// IsValidEventStatus reports whether status is part of the event lifecycle
+func IsValidEventStatus(status string) bool {
+	_, ok := eventStatusTransitions[status]
+	return ok
+}
+
+// CanTransitionEventStatus reports whether an event may move from one status to another
+func CanTransitionEventStatus(from, to string) bool {
+	for _, next := range eventStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Registration statuses
+const (
+	RegistrationStatusConfirmed  = "confirmed"
+	RegistrationStatusWaitlisted = "waitlisted"
+)
+
+// Registration represents a user's seat at an event.
+// Position is the 1-based place on the waitlist and is zero for confirmed seats.
+type Registration struct {
+	ID        uuid.UUID  `json:"id"`
+	EventID   uuid.UUID  `json:"event_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Status    string     `json:"status"`
+	Position  int        `json:"waitlist_position,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
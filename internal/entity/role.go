@@ -0,0 +1,50 @@
+package entity
+
+// Role represents a named group of permissions
+type Role struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Permission represents a single capability such as "events:delete"
+type Permission struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Built-in roles
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Built-in permissions. PermissionEventsAdmin lets the holder modify events they do not organize.
+const (
+	PermissionEventsRead     = "events:read"
+	PermissionEventsCreate   = "events:create"
+	PermissionEventsUpdate   = "events:update"
+	PermissionEventsDelete   = "events:delete"
+	PermissionEventsManage   = "events:manage"
+	PermissionEventsRegister = "events:register"
+	PermissionEventsAdmin    = "events:admin"
+	PermissionUsersList      = "users:list"
+	PermissionUsersRead      = "users:read"
+	PermissionUsersUpdate    = "users:update"
+	PermissionUsersDelete    = "users:delete"
+	PermissionUsersUnlock    = "users:unlock"
+	PermissionRolesAssign    = "roles:assign"
+)
+
+// DefaultRolePermissions is the permission set seeded for each built-in role
+var DefaultRolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionEventsRead, PermissionEventsCreate, PermissionEventsUpdate, PermissionEventsDelete,
+		PermissionEventsManage, PermissionEventsRegister, PermissionEventsAdmin,
+		PermissionUsersList, PermissionUsersRead, PermissionUsersUpdate, PermissionUsersDelete,
+		PermissionUsersUnlock, PermissionRolesAssign,
+	},
+	RoleUser: {
+		PermissionEventsRead, PermissionEventsCreate, PermissionEventsUpdate, PermissionEventsDelete,
+		PermissionEventsManage, PermissionEventsRegister,
+	},
+}
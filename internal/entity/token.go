@@ -6,12 +6,28 @@ import (
 	"github.com/gofrs/uuid"
 )
 
-// Token represents a token entity
+// Token types
+const (
+	TokenTypeAccess       = "access"
+	TokenTypeRefresh      = "refresh"
+	TokenTypeReset        = "password_reset"
+	TokenTypeVerification = "email_verification"
+	TokenTypeChallenge    = "two_factor_challenge"
+)
+
+// Token represents a token entity.
+// Refresh tokens issued from the same login share a FamilyID; UsedAt is set
+// once a refresh token has been exchanged.
 type Token struct {
 	ID        uuid.UUID  `json:"id"`
 	UserID    uuid.UUID  `json:"user_id"`
 	Token     string     `json:"token"`
+	Type      string     `json:"type"`
+	FamilyID  uuid.UUID  `json:"family_id"`
 	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	UserAgent string     `json:"user_agent"`
+	IPAddress string     `json:"ip_address"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
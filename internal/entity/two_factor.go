@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// TwoFactor holds a user's TOTP enrollment.
+// ConfirmedAt stays nil until the user proves their authenticator works;
+// only confirmed enrollments are enforced at login.
+type TwoFactor struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	Secret       string     `json:"-"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedStep int64      `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Enabled reports whether two-factor authentication is enforced for the user.
+func (t *TwoFactor) Enabled() bool {
+	return t.ConfirmedAt != nil
+}
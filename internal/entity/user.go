@@ -11,7 +11,7 @@ type User struct {
 	ID        uuid.UUID  `json:"id"`
 	Username  string     `json:"username"`
 	Email     string     `json:"email"`
-	Password  string     `json:"password"`
+	Password  string     `json:"-"`
 	FirstName string     `json:"first_name"`
 	LastName  string     `json:"last_name"`
 	IsActive  bool       `json:"is_active"`
@@ -19,4 +19,7 @@ type User struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// EmailVerifiedAt is nil until the user confirms their email address
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 }
@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS login_attempts;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS user_two_factor;
+DROP TABLE IF EXISTS registrations;
+DROP TABLE IF EXISTS event_organizers;
+DROP TABLE IF EXISTS events;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS user_permissions;
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS tokens;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,145 @@
+-- Baseline schema. Every statement is idempotent so databases created by the
+-- old CreateTables bootstrap can adopt migrations without being rebuilt.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS users (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	username VARCHAR(255) UNIQUE NOT NULL,
+	email VARCHAR(255) UNIQUE NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	first_name VARCHAR(255),
+	last_name VARCHAR(255),
+	is_active BOOLEAN DEFAULT FALSE,
+	last_login TIMESTAMP,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP
+);
+
+-- Accounts created before email verification existed are treated as verified
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+		WHERE table_name = 'users' AND column_name = 'email_verified_at') THEN
+		ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP NULL;
+		UPDATE users SET email_verified_at = created_at;
+	END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS tokens (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	token VARCHAR(255) UNIQUE NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+ALTER TABLE tokens
+	ADD COLUMN IF NOT EXISTS type TEXT NOT NULL DEFAULT 'access',
+	ADD COLUMN IF NOT EXISTS family_id UUID,
+	ADD COLUMN IF NOT EXISTS used_at TIMESTAMP NULL,
+	ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS ip_address TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS token_hashed BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS roles (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+	user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+	role_id INT REFERENCES roles(id) ON DELETE CASCADE,
+	PRIMARY KEY (user_id, role_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_permissions (
+	user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+	permission_id INT REFERENCES permissions(id) ON DELETE CASCADE,
+	PRIMARY KEY (user_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+	role_id INT REFERENCES roles(id) ON DELETE CASCADE,
+	permission_id INT REFERENCES permissions(id) ON DELETE CASCADE,
+	PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS events (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	title VARCHAR(255) NOT NULL,
+	description TEXT,
+	location VARCHAR(255),
+	start_time TIMESTAMP NOT NULL,
+	end_time TIMESTAMP,
+	time_zone TEXT NOT NULL DEFAULT 'UTC',
+	capacity INTEGER NOT NULL CHECK (capacity >= 0),
+	is_public BOOLEAN DEFAULT true,
+	status TEXT NOT NULL,
+	organizer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE IF NOT EXISTS event_organizers (
+	event_id UUID REFERENCES events(id) ON DELETE CASCADE,
+	user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+	PRIMARY KEY (event_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS registrations (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	status TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	UNIQUE (event_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_two_factor (
+	user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+	secret TEXT NOT NULL,
+	confirmed_at TIMESTAMP NULL,
+	last_used_step BIGINT NOT NULL DEFAULT 0,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+	id SERIAL PRIMARY KEY,
+	user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	code_hash TEXT NOT NULL,
+	used_at TIMESTAMP NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (user_id, code_hash)
+);
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+	key TEXT PRIMARY KEY,
+	failures INTEGER NOT NULL DEFAULT 0,
+	last_failure_at TIMESTAMP NOT NULL,
+	locked_until TIMESTAMP NULL
+);
+
+CREATE TABLE IF NOT EXISTS audit_events (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	type TEXT NOT NULL,
+	user_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+	actor_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+	ip_address TEXT NOT NULL DEFAULT '',
+	detail TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS events_deleted_at_idx;
+DROP INDEX IF EXISTS users_deleted_at_idx;
+DROP INDEX IF EXISTS users_email_live_key;
+DROP INDEX IF EXISTS users_username_live_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
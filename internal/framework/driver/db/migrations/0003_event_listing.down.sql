@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS events_title_search_idx;
+DROP INDEX IF EXISTS events_organizer_id_idx;
+DROP INDEX IF EXISTS events_title_id_idx;
+DROP INDEX IF EXISTS events_created_at_id_idx;
+DROP INDEX IF EXISTS events_start_time_id_idx;
@@ -0,0 +1,9 @@
+-- Event listings are keyset paginated on (sort column, id), so each sort
+-- order needs a matching index over live events.
+CREATE INDEX IF NOT EXISTS events_start_time_id_idx ON events (start_time, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS events_created_at_id_idx ON events (created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS events_title_id_idx ON events (title, id) WHERE deleted_at IS NULL;
+
+-- Filters
+CREATE INDEX IF NOT EXISTS events_organizer_id_idx ON events (organizer_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS events_title_search_idx ON events USING GIN (to_tsvector('simple', title)) WHERE deleted_at IS NULL;
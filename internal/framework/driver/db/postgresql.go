@@ -3,8 +3,9 @@ package db
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
 	_ "github.com/lib/pq"
 )
@@ -22,89 +23,42 @@ func ConnectDB(cfg *config.DBConfig) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to ping the database: %v", err)
 	}
 
-	log.Println("Successfully connected to the database")
+	slog.Info("Successfully connected to the database")
 
 	return db, nil
 }
 
-// CreateTables ensures that the required tables are created.
-func CreateTables(db *sql.DB) error {
-	userTable := `CREATE TABLE IF NOT EXISTS users (
-	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-	username VARCHAR(255) UNIQUE NOT NULL,
-	email VARCHAR(255) UNIQUE NOT NULL,
-	password VARCHAR(255) NOT NULL,
-	first_name VARCHAR(255),
-	last_name VARCHAR(255),
-	is_active BOOLEAN DEFAULT FALSE,
-	last_login TIMESTAMP,
-	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-	deleted_at TIMESTAMP
-	);`
-
-	//
-	eventTable := `CREATE TABLE IF NOT EXISTS events (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			title VARCHAR(255) NOT NULL,
-			description TEXT,
-			location VARCHAR(255),
-			start_time TIMESTAMP NOT NULL,
-			end_time TIMESTAMP,
-			capacity INTEGER NOT NULL CHECK (capacity >= 0),
-			is_public BOOLEAN DEFAULT true,
-			status TEXT NOT NULL,
-			organizer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			deleted_at TIMESTAMP NULL
-			);`
-
-	// Create tokens table
-	tokenTable := `CREATE TABLE IF NOT EXISTS tokens (
-    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    token VARCHAR(255) UNIQUE NOT NULL,
-    expires_at TIMESTAMP NOT NULL,
-    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, -- Corrected: No 'UPDATE CURRENT_TIMESTAMP' here
-    deleted_at TIMESTAMP NULL
-);
-`
-
-	// Create roles table
-	roleTable := `CREATE TABLE IF NOT EXISTS roles (
-	id SERIAL PRIMARY KEY,
-	name VARCHAR(255) UNIQUE NOT NULL
-	);`
-
-	// Create permissions table
-	permissionTable := `CREATE TABLE IF NOT EXISTS permissions (
-	id  SERIAL PRIMARY KEY,
-	name VARCHAR(255) UNIQUE NOT NULL 
-	);`
-
-	// Create user_roles table
-	createRoleTable := `CREATE TABLE IF NOT EXISTS user_roles (
-	user_id UUID REFERENCES users(id) ON DELETE CASCADE,
-	role_id INT REFERENCES roles(id) ON DELETE CASCADE,
-	PRIMARY KEY (user_id, role_id)
-	);`
+// SeedRoles ensures the built-in roles and their permissions exist, and gives
+// the default user role to any user that has no role yet.
+func SeedRoles(db *sql.DB) error {
+	for role, permissions := range entity.DefaultRolePermissions {
+		if _, err := db.Exec(`INSERT INTO roles (name) VALUES ($1) ON CONFLICT (name) DO NOTHING`, role); err != nil {
+			return fmt.Errorf("failed to seed role %s: %v", role, err)
+		}
 
-	// Create user_permissions table
-	userPermissionTable := `CREATE TABLE IF NOT EXISTS user_permissions (
-	user_id UUID REFERENCES users(id) ON DELETE CASCADE,
-	permission_id INT REFERENCES permissions(id) ON DELETE CASCADE,
-	PRIMARY KEY(user_id, permission_id)
-	);`
+		for _, permission := range permissions {
+			if _, err := db.Exec(`INSERT INTO permissions (name) VALUES ($1) ON CONFLICT (name) DO NOTHING`, permission); err != nil {
+				return fmt.Errorf("failed to seed permission %s: %v", permission, err)
+			}
 
-	// Execute the table creation queries
-	query := []string{userTable, tokenTable, roleTable, permissionTable, createRoleTable, userPermissionTable, eventTable}
-	for _, query := range query {
-		if _, err := db.Exec(query); err != nil {
-			return fmt.Errorf("failed to create table: %v", err)
+			query := `INSERT INTO role_permissions (role_id, permission_id)
+				SELECT r.id, p.id FROM roles r, permissions p
+				WHERE r.name = $1 AND p.name = $2
+				ON CONFLICT DO NOTHING`
+			if _, err := db.Exec(query, role, permission); err != nil {
+				return fmt.Errorf("failed to grant %s to role %s: %v", permission, role, err)
+			}
 		}
-		log.Println("Successfully created all tables")
 	}
+
+	backfill := `INSERT INTO user_roles (user_id, role_id)
+		SELECT u.id, r.id FROM users u, roles r
+		WHERE r.name = $1
+		AND NOT EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_id = u.id)`
+	if _, err := db.Exec(backfill, entity.RoleUser); err != nil {
+		return fmt.Errorf("failed to assign default role: %v", err)
+	}
+
+	slog.Info("Successfully seeded roles and permissions")
 	return nil
 }
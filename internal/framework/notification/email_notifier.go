@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/service"
+	"github.com/gofrs/uuid"
+)
+
+// emailNotifier delivers notifications to the user's email address.
+type emailNotifier struct {
+	userRepo repository.UserRepository
+	mailer   service.Mailer
+}
+
+// NewEmailNotifier creates a Notifier that emails users through the mailer.
+func NewEmailNotifier(userRepo repository.UserRepository, mailer service.Mailer) service.Notifier {
+	return &emailNotifier{userRepo: userRepo, mailer: mailer}
+}
+
+// Notify implements service.Notifier.
+func (n *emailNotifier) Notify(ctx context.Context, userID uuid.UUID, subject, message string) error {
+	user, err := n.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user to notify: %v", err)
+	}
+
+	return n.mailer.Send(ctx, user.Email, subject, message)
+}
@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/service"
+)
+
+// fileMailer appends outgoing mail to a local file instead of sending it.
+type fileMailer struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileMailer creates a Mailer that writes messages to the file at path.
+func NewFileMailer(path string) service.Mailer {
+	return &fileMailer{path: path}
+}
+
+// Send implements service.Mailer.
+func (m *fileMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail file: %v", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s\tto=%s\tsubject=%s\n%s\n\n", time.Now().UTC().Format(time.RFC3339), to, subject, body)
+	return err
+}
@@ -0,0 +1,38 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/service"
+	"github.com/gofrs/uuid"
+)
+
+// fileNotifier appends notifications to a local file, standing in for real delivery during development.
+type fileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileNotifier creates a Notifier that writes messages to the file at path.
+func NewFileNotifier(path string) service.Notifier {
+	return &fileNotifier{path: path}
+}
+
+// Notify implements service.Notifier.
+func (n *fileNotifier) Notify(ctx context.Context, userID uuid.UUID, subject, message string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open notification file: %v", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s\tuser=%s\tsubject=%s\n%s\n\n", time.Now().UTC().Format(time.RFC3339), userID, subject, message)
+	return err
+}
@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+func TestLogNotifierOmitsMessage(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := logger.WithContext(context.Background(), logger.New(&buf, &config.LogConfig{
+		Level:        "debug",
+		Format:       "json",
+		RedactFields: []string{"password", "token", "email", "secret", "code"},
+	}))
+
+	const resetCode = "q9Xv2LrT8mWcZ4kN"
+	userID := uuid.Must(uuid.NewV4())
+	message := "Use this code to reset your password: " + resetCode
+
+	if err := NewLogNotifier().Notify(ctx, userID, "Reset your password", message); err != nil {
+		t.Fatalf("Notify returned %v", err)
+	}
+
+	logged := buf.String()
+	if strings.Contains(logged, resetCode) {
+		t.Errorf("reset code was written to the log: %s", logged)
+	}
+	if !strings.Contains(logged, userID.String()) || !strings.Contains(logged, "Reset your password") {
+		t.Errorf("log is missing the user ID or subject: %s", logged)
+	}
+}
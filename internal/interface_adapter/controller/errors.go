@@ -0,0 +1,22 @@
+package controller
+
+import "example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+
+// Errors for malformed path parameters and requests. Handlers hand these and
+// service errors to c.Error; ErrorMiddleware writes the response.
+var (
+	errInvalidEventID   = apperror.NewBadRequest("invalid event ID")
+	errInvalidUserID    = apperror.NewBadRequest("invalid user ID")
+	errInvalidSessionID = apperror.NewBadRequest("invalid session ID")
+	errUserRequired     = apperror.NewUnauthorized("user ID is required")
+)
+
+// invalidRequest reports a request body that could not be bound.
+func invalidRequest(err error) error {
+	return apperror.Wrap(apperror.BadRequest, "invalid request body", err)
+}
+
+// invalidQuery reports a query string that could not be bound.
+func invalidQuery(err error) error {
+	return apperror.Wrap(apperror.BadRequest, "invalid query parameters", err)
+}
@@ -5,6 +5,7 @@ import (
 
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/service"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/middlewares"
 	"github.com/gin-gonic/gin"
 	"github.com/gofrs/uuid"
 )
@@ -22,29 +23,34 @@ func NewEventController(eventService service.EventService) *EventController {
 
 // CreateEvent handles the creation of a new event
 func (c *EventController) CreateEvent(ctx *gin.Context) {
-	var event entity.Event
+	var request CreateEventRequest
 
-	if err := ctx.ShouldBindJSON(&event); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(ctx, &request); err != nil {
+		ctx.Error(err)
 		return
 	}
 
 	OrganizerID, exists := ctx.Get("userID")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "organizer ID is required"})
+		ctx.Error(errUserRequired)
 		return
 	}
 
 	createdEvent, err := c.eventService.CreateEvent(
-		event.Title,
-		event.Description,
-		event.Location,
-		event.Capacity,
-		event.Status,
+		ctx.Request.Context(),
+		request.Title,
+		request.Description,
+		request.Location,
+		request.StartTime,
+		request.EndTime,
+		request.TimeZone,
+		request.Capacity,
+		request.Status,
+		request.isPublic(),
 		OrganizerID.(uuid.UUID),
 	)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 	ctx.JSON(http.StatusOK, createdEvent)
@@ -52,26 +58,38 @@ func (c *EventController) CreateEvent(ctx *gin.Context) {
 
 // Updateevent handles the update of an existing event
 func (c *EventController) UpdateEvent(ctx *gin.Context) {
-	var event entity.Event
+	var request UpdateEventRequest
 
 	eventIdparam := ctx.Param("id")
 	eventID, err := uuid.FromString(eventIdparam)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid event ID"})
+		ctx.Error(errInvalidEventID)
 		return
 	}
 
-	// Bind JSON input to event struct
-	if err := ctx.ShouldBindJSON(&event); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	// Bind JSON input to the update request
+	if err := bindJSON(ctx, &request); err != nil {
+		ctx.Error(err)
 		return
 	}
 
-	event.ID = eventID
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	// Start from the stored event so settings that were not sent are kept
+	event, err := c.eventService.GetEventByID(ctx.Request.Context(), userID.(uuid.UUID), eventID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	request.apply(event)
 
 	// Call service to update event
-	if err := c.eventService.UpdateEvent(&event); err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	if err := c.eventService.UpdateEvent(ctx.Request.Context(), userID.(uuid.UUID), event); err != nil {
+		ctx.Error(err)
 		return
 	}
 
@@ -83,12 +101,18 @@ func (c *EventController) DeleteEvent(ctx *gin.Context) {
 	eventIdparam := ctx.Param("id")
 	eventID, err := uuid.FromString(eventIdparam)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
 		return
 	}
 
-	if err := c.eventService.DeleteEvent(eventID); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := c.eventService.DeleteEvent(ctx.Request.Context(), userID.(uuid.UUID), eventID); err != nil {
+		ctx.Error(err)
 		return
 	}
 
@@ -100,25 +124,315 @@ func (c *EventController) GetEventByID(ctx *gin.Context) {
 	eventIdparam := ctx.Param("id")
 	eventID, err := uuid.FromString(eventIdparam)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		ctx.Error(errInvalidEventID)
 		return
 	}
 
-	event, err := c.eventService.GetEventByID(eventID)
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	event, err := c.eventService.GetEventByID(ctx.Request.Context(), userID.(uuid.UUID), eventID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, event)
 }
 
+// ListtAllEvents returns one page of events matching the query string
 func (c *EventController) ListtAllEvents(ctx *gin.Context) {
-	events, err := c.eventService.ListEvent()
+	var request ListEventsQuery
+
+	if err := bindQuery(ctx, &request); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	// Administrators also see other organizers' private and draft events
+	viewAll := middlewares.HasPermission(ctx, entity.PermissionEventsAdmin)
+
+	list, err := c.eventService.ListEvents(ctx.Request.Context(), request.toQuery(userID.(uuid.UUID), viewAll))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, EventListResponse{
+		Events:     list.Events,
+		NextCursor: list.NextCursor,
+		Total:      list.Total,
+	})
+}
+
+// PublishEvent makes a draft event visible
+func (c *EventController) PublishEvent(ctx *gin.Context) {
+	c.transitionEvent(ctx, entity.EventStatusPublished)
+}
+
+// UnpublishEvent returns a published event to draft
+func (c *EventController) UnpublishEvent(ctx *gin.Context) {
+	c.transitionEvent(ctx, entity.EventStatusDraft)
+}
+
+// OpenRegistration starts accepting registrations for an event
+func (c *EventController) OpenRegistration(ctx *gin.Context) {
+	c.transitionEvent(ctx, entity.EventStatusRegistrationOpen)
+}
+
+// CloseRegistration stops accepting registrations for an event
+func (c *EventController) CloseRegistration(ctx *gin.Context) {
+	c.transitionEvent(ctx, entity.EventStatusRegistrationClosed)
+}
+
+// StartEvent marks an event as ongoing
+func (c *EventController) StartEvent(ctx *gin.Context) {
+	c.transitionEvent(ctx, entity.EventStatusOngoing)
+}
+
+// CompleteEvent marks an ongoing event as completed
+func (c *EventController) CompleteEvent(ctx *gin.Context) {
+	c.transitionEvent(ctx, entity.EventStatusCompleted)
+}
+
+// CancelEvent cancels an event that has not yet completed
+func (c *EventController) CancelEvent(ctx *gin.Context) {
+	c.transitionEvent(ctx, entity.EventStatusCancelled)
+}
+
+// ArchiveEvent archives a completed or cancelled event
+func (c *EventController) ArchiveEvent(ctx *gin.Context) {
+	c.transitionEvent(ctx, entity.EventStatusArchived)
+}
+
+// transitionEvent moves the event in the URL to the given status
+func (c *EventController) transitionEvent(ctx *gin.Context, status string) {
+	eventID, err := uuid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	event, err := c.eventService.TransitionEvent(ctx.Request.Context(), userID.(uuid.UUID), eventID, status)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, event)
+}
+
+// RegisterForEvent books a seat at an event for the authenticated user
+func (c *EventController) RegisterForEvent(ctx *gin.Context) {
+	eventID, err := uuid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	registration, err := c.eventService.RegisterForEvent(ctx.Request.Context(), eventID, userID.(uuid.UUID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, registration)
+}
+
+// GetRegistration returns the authenticated user's registration, including waitlist position
+func (c *EventController) GetRegistration(ctx *gin.Context) {
+	eventID, err := uuid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	registration, err := c.eventService.GetRegistration(ctx.Request.Context(), eventID, userID.(uuid.UUID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registration)
+}
+
+// CancelRegistration releases the authenticated user's seat at an event
+func (c *EventController) CancelRegistration(ctx *gin.Context) {
+	eventID, err := uuid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	if err := c.eventService.CancelRegistration(ctx.Request.Context(), eventID, userID.(uuid.UUID)); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "registration cancelled successfully"})
+}
+
+// ListRegistrations returns the registrations for an event
+func (c *EventController) ListRegistrations(ctx *gin.Context) {
+	eventID, err := uuid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	registrations, err := c.eventService.ListRegistrations(ctx.Request.Context(), userID.(uuid.UUID), eventID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registrations)
+}
+
+// ListCoOrganizers returns the IDs of an event's co-organizers
+func (c *EventController) ListCoOrganizers(ctx *gin.Context) {
+	eventID, err := uuid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	userIDs, err := c.eventService.ListCoOrganizers(ctx.Request.Context(), userID.(uuid.UUID), eventID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"coorganizers": userIDs})
+}
+
+// AddCoOrganizer lets another user help organize an event
+func (c *EventController) AddCoOrganizer(ctx *gin.Context) {
+	var request struct {
+		UserID uuid.UUID `json:"user_id" binding:"required"`
+	}
+
+	eventID, err := uuid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	if err := bindJSON(ctx, &request); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	if err := c.eventService.AddCoOrganizer(ctx.Request.Context(), userID.(uuid.UUID), eventID, request.UserID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "co-organizer added successfully"})
+}
+
+// RemoveCoOrganizer revokes a user's co-organizer access to an event
+func (c *EventController) RemoveCoOrganizer(ctx *gin.Context) {
+	eventID, err := uuid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	coOrganizerID, err := uuid.FromString(ctx.Param("userID"))
+	if err != nil {
+		ctx.Error(errInvalidUserID)
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.Error(errUserRequired)
+		return
+	}
+
+	if err := c.eventService.RemoveCoOrganizer(ctx.Request.Context(), userID.(uuid.UUID), eventID, coOrganizerID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "co-organizer removed successfully"})
+}
+
+// ListDeletedEvents lists soft-deleted events for administrators
+func (c *EventController) ListDeletedEvents(ctx *gin.Context) {
+	events, err := c.eventService.ListDeletedEvents(ctx.Request.Context())
+	if err != nil {
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, events)
 }
+
+// RestoreEvent undoes the soft delete of an event
+func (c *EventController) RestoreEvent(ctx *gin.Context) {
+	eventID, err := uuid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(errInvalidEventID)
+		return
+	}
+
+	if err := c.eventService.RestoreEvent(ctx.Request.Context(), eventID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "event restored successfully"})
+}
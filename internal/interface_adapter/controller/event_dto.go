@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/service"
+	"github.com/gofrs/uuid"
+)
+
+// CreateEventRequest is the body accepted when creating an event. Events are
+// public unless is_public is false.
+type CreateEventRequest struct {
+	Title       string    `json:"title" binding:"required,max=255"`
+	Description string    `json:"description" binding:"max=10000"`
+	Location    string    `json:"location" binding:"max=255"`
+	StartTime   time.Time `json:"starttime" binding:"required"`
+	EndTime     time.Time `json:"endtime" binding:"required,gtfield=StartTime"`
+	TimeZone    string    `json:"timezone" binding:"omitempty,timezone"`
+	Capacity    int       `json:"capacity" binding:"required,capacity"`
+	Status      string    `json:"status" binding:"omitempty,eventstatus"`
+	IsPublic    *bool     `json:"is_public"`
+}
+
+// isPublic reports whether the event should be public, defaulting to true
+func (r *CreateEventRequest) isPublic() bool {
+	return r.IsPublic == nil || *r.IsPublic
+}
+
+// UpdateEventRequest is the body accepted when editing an event. It replaces
+// every editable field except is_public, which is kept when omitted; the
+// status can only be repeated, not changed.
+type UpdateEventRequest struct {
+	CreateEventRequest
+}
+
+// apply copies the request onto the stored event
+func (r *UpdateEventRequest) apply(event *entity.Event) {
+	event.Title = r.Title
+	event.Description = r.Description
+	event.Location = r.Location
+	event.StartTime = r.StartTime
+	event.EndTime = r.EndTime
+	event.TimeZone = r.TimeZone
+	event.Capacity = r.Capacity
+	event.Status = r.Status
+	if r.IsPublic != nil {
+		event.IsPublic = *r.IsPublic
+	}
+}
+
+// ListEventsQuery is the query string accepted when listing events. Sort
+// takes a "-" prefix to sort descending; Cursor is the next_cursor of the
+// previous page and must be used with the same sort.
+type ListEventsQuery struct {
+	Status      string    `form:"status" binding:"omitempty,eventstatus"`
+	OrganizerID string    `form:"organizer_id" binding:"omitempty,uuid"`
+	Location    string    `form:"location" binding:"max=255"`
+	From        time.Time `form:"from"`
+	To          time.Time `form:"to" binding:"omitempty,gtfield=From"`
+	IsPublic    *bool     `form:"is_public"`
+	Search      string    `form:"q" binding:"max=255"`
+	Sort        string    `form:"sort" binding:"omitempty,oneof=start_time -start_time created_at -created_at title -title"`
+	Cursor      string    `form:"cursor"`
+	Limit       int       `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// toQuery builds the service query the request describes, listing the
+// events the viewer may see, or every event for an administrator
+func (q *ListEventsQuery) toQuery(viewerID uuid.UUID, viewAll bool) service.EventListQuery {
+	// The uuid rule has already checked the organizer ID
+	organizerID, _ := uuid.FromString(q.OrganizerID)
+
+	return service.EventListQuery{
+		Status:      q.Status,
+		OrganizerID: organizerID,
+		Location:    q.Location,
+		From:        optionalTime(q.From),
+		To:          optionalTime(q.To),
+		IsPublic:    q.IsPublic,
+		Search:      q.Search,
+		ViewerID:    viewerID,
+		ViewAll:     viewAll,
+		Sort:        q.Sort,
+		Cursor:      q.Cursor,
+		Limit:       q.Limit,
+	}
+}
+
+// optionalTime treats the zero time of an omitted parameter as no time
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// EventListResponse is one page of events. NextCursor is omitted on the last
+// page; Total counts the matching events on every page.
+type EventListResponse struct {
+	Events     []*entity.Event `json:"events"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	Total      int             `json:"total"`
+}
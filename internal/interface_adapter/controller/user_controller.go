@@ -1,11 +1,11 @@
 package controller
 
 import (
-	"log"
 	"net/http"
 
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/service"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/middlewares"
 	"github.com/gin-gonic/gin"
 	"github.com/gofrs/uuid"
 )
@@ -22,85 +22,349 @@ func NewUserController(userService service.UserService) *UserController {
 
 // CreateUser creates a new user
 func (uc *UserController) RegisterUser(c *gin.Context) {
-	var user entity.User
+	var request RegisterUserRequest
 
-	// Bind incoming JSON to the user struct
-	if err := c.ShouldBindJSON(&user); err != nil {
-		log.Printf("Error binding JSON: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	// Bind incoming JSON to the registration request
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
 		return
 	}
 
-	log.Printf("Bound User Struct: %+v", user)
-
 	// Call the service layer to handle user registration
-	createdUser, err := uc.userService.RegisterUser(user.Username, user.Email, user.Password, user.FirstName, user.LastName)
+	createdUser, err := uc.userService.RegisterUser(c.Request.Context(), request.Username, request.Email, request.Password, request.FirstName, request.LastName)
 	if err != nil {
-		log.Printf("Error registering user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, createdUser)
+	c.JSON(http.StatusOK, newUserProfileResponse(createdUser))
 }
 
 // Authenticate User
 func (uc *UserController) AuthenticateUser(c *gin.Context) {
-	var user entity.User
+	var request AuthenticateUserRequest
 
-	// Bind incoming JSON to the user struct
-	if err := c.ShouldBindJSON(&user); err != nil {
-		log.Printf("Error binding JSON: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	// Bind incoming JSON to the sign-in request
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
 		return
 	}
 
-	log.Printf("Bound User Struct: %+v", user)
-
 	// Call the service layer to handle user authentication
-	authenticatedUser, err := uc.userService.AuthenticateUser(user.Email, user.Password)
+	loginResult, challenge, err := uc.userService.AuthenticateUser(c.Request.Context(), request.Email, request.Password, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// The password was right but a second factor is still needed
+	if challenge != nil {
+		c.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	c.JSON(http.StatusOK, newLoginResponse(loginResult))
+}
+
+// CompleteTwoFactorLogin exchanges a login challenge and a two-factor code for tokens
+func (uc *UserController) CompleteTwoFactorLogin(c *gin.Context) {
+	var request struct {
+		ChallengeToken string `json:"challenge_token" binding:"required"`
+		Code           string `json:"code" binding:"required"`
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	loginResult, err := uc.userService.CompleteTwoFactorLogin(c.Request.Context(), request.ChallengeToken, request.Code, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newLoginResponse(loginResult))
+}
+
+// SetupTwoFactor starts two-factor enrollment for the signed-in user
+func (uc *UserController) SetupTwoFactor(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	setup, err := uc.userService.SetupTwoFactor(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// ConfirmTwoFactor enables two-factor authentication once the user proves their authenticator works
+func (uc *UserController) ConfirmTwoFactor(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	var request struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	codes, err := uc.userService.ConfirmTwoFactor(c.Request.Context(), userID, request.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication enabled", "recovery_codes": codes})
+}
+
+// DisableTwoFactor turns off two-factor authentication for the signed-in user
+func (uc *UserController) DisableTwoFactor(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	var request struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := uc.userService.DisableTwoFactor(c.Request.Context(), userID, request.Code); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes replaces the signed-in user's recovery codes
+func (uc *UserController) RegenerateRecoveryCodes(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	var request struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	codes, err := uc.userService.RegenerateRecoveryCodes(c.Request.Context(), userID, request.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+// RefreshToken exchanges a refresh token for a new token pair
+func (uc *UserController) RefreshToken(c *gin.Context) {
+	var request struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	loginResult, err := uc.userService.RefreshToken(c.Request.Context(), request.RefreshToken, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newLoginResponse(loginResult))
+}
+
+// Logout signs out the session the request was made with
+func (uc *UserController) Logout(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+	sessionID := c.MustGet("sessionID").(uuid.UUID)
+
+	if err := uc.userService.Logout(c.Request.Context(), userID, sessionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// LogoutAll signs the user out of every session
+func (uc *UserController) LogoutAll(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	if err := uc.userService.LogoutAll(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out of all sessions"})
+}
+
+// ListSessions returns the user's active sessions
+func (uc *UserController) ListSessions(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+	sessionID := c.MustGet("sessionID").(uuid.UUID)
+
+	sessions, err := uc.userService.ListSessions(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+// RevokeSession signs out one of the user's sessions
+func (uc *UserController) RevokeSession(c *gin.Context) {
+	userID := c.MustGet("userID").(uuid.UUID)
+
+	sessionID, err := uuid.FromString(c.Param("id"))
 	if err != nil {
-		log.Printf("Error authenticating user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(errInvalidSessionID)
+		return
+	}
+
+	if err := uc.userService.Logout(c.Request.Context(), userID, sessionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
+
+// ForgotPassword sends a password reset code to the given email
+func (uc *UserController) ForgotPassword(c *gin.Context) {
+	var request struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := uc.userService.RequestPasswordReset(c.Request.Context(), request.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Same answer whether or not the email is registered
+	c.JSON(http.StatusOK, gin.H{"message": "If the email is registered, a reset code has been sent"})
+}
+
+// ResetPassword sets a new password using a reset code
+func (uc *UserController) ResetPassword(c *gin.Context) {
+	var request struct {
+		Token       string `json:"token" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required,password"`
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := uc.userService.ResetPassword(c.Request.Context(), request.Token, request.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+// VerifyEmail confirms a user's email address using a verification code
+func (uc *UserController) VerifyEmail(c *gin.Context) {
+	var request struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := uc.userService.VerifyEmail(c.Request.Context(), request.Token); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// ResendVerification sends a new verification code
+func (uc *UserController) ResendVerification(c *gin.Context) {
+	var request struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, authenticatedUser)
+	if err := uc.userService.ResendVerification(c.Request.Context(), request.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Same answer whether or not the email is registered
+	c.JSON(http.StatusOK, gin.H{"message": "If the email is registered and unverified, a verification code has been sent"})
+}
+
+// clientInfo captures the device details recorded with a session
+func clientInfo(c *gin.Context) service.ClientInfo {
+	return service.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
 }
 
 // update user
 func (uc *UserController) UpdateUser(c *gin.Context) {
-	var user entity.User
+	var request UpdateUserRequest
 
 	// Get the user ID from the URL parameter
 	userIDParam := c.Param("id")
 	userID, err := uuid.FromString(userIDParam)
 	if err != nil {
-		log.Printf("Invalid user ID: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(errInvalidUserID)
+		return
+	}
+
+	// Bind incoming JSON to the update request
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
 		return
 	}
 
-	// Bind incoming JSON to the user struct
-	if err := c.ShouldBindJSON(&user); err != nil {
-		log.Printf("Error binding JSON: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data"})
+	// Start from the stored user so fields that were not sent, such as the password, are kept
+	user, err := uc.userService.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	log.Printf("Bound User Struct: %+v", user)
 
-	// Set the user ID from the params into the user struct
-	user.ID = userID
+	admin := middlewares.HasPermission(c, entity.PermissionUsersUpdate)
+	request.apply(user, admin)
 
 	// Call the service layer to handle user update
-	if err := uc.userService.UpdateUser(&user); err != nil {
-		log.Printf("Error updating user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := uc.userService.UpdateUser(c.Request.Context(), user); err != nil {
+		c.Error(err)
 		return
 	}
 
 	// Respond with success
-	c.JSON(http.StatusOK, gin.H{"message": "User updated successfully", "user": user})
+	c.JSON(http.StatusOK, gin.H{"message": "User updated successfully", "user": newUserResponse(user, admin)})
 }
 
 // delete user
@@ -109,15 +373,13 @@ func (uc *UserController) DeleteUser(c *gin.Context) {
 	userIDParam := c.Param("id")
 	userID, err := uuid.FromString(userIDParam)
 	if err != nil {
-		log.Printf("Invalid user ID: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(errInvalidUserID)
 		return
 	}
 
 	// Call the service layer to handle user deletion
-	if err := uc.userService.DeleteUser(userID); err != nil {
-		log.Printf("Error deleting user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := uc.userService.DeleteUser(c.Request.Context(), userID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -130,34 +392,144 @@ func (uc *UserController) GetUserByID(c *gin.Context) {
 	userIDParam := c.Param("id")
 	userID, err := uuid.FromString(userIDParam)
 	if err != nil {
-		log.Printf("Invalid user ID: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(errInvalidUserID)
 		return
 	}
 
 	// Call the service layer to handle user deletion
-	user, err := uc.userService.GetUserByID(userID)
+	user, err := uc.userService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
-		log.Printf("Error deleting user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
-
 	}
 
 	// Respond with success
-	c.JSON(http.StatusOK, gin.H{"user": user})
+	c.JSON(http.StatusOK, gin.H{"user": newUserResponse(user, middlewares.HasPermission(c, entity.PermissionUsersRead))})
 }
 
 func (uc *UserController) ListUsers(c *gin.Context) {
 
 	// Call the service layer to handle user deletion
-	user, err := uc.userService.ListUsers()
+	users, err := uc.userService.ListUsers(c.Request.Context())
 	if err != nil {
-		log.Printf("Error deleting user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	// Only administrators can list users, so they get the admin view
+	response := make([]AdminUserResponse, 0, len(users))
+	for _, user := range users {
+		response = append(response, newAdminUserResponse(user))
+	}
+
 	// Respond with success
-	c.JSON(http.StatusOK, gin.H{"user": user})
+	c.JSON(http.StatusOK, gin.H{"user": response})
+}
+
+// ListRoles returns the roles assigned to a user
+func (uc *UserController) ListRoles(c *gin.Context) {
+	userID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidUserID)
+		return
+	}
+
+	roles, err := uc.userService.ListRoles(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"roles": roles})
+}
+
+// AssignRole gives a user a role
+func (uc *UserController) AssignRole(c *gin.Context) {
+	var request struct {
+		Role string `json:"role" binding:"required"`
+	}
+
+	userID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidUserID)
+		return
+	}
+
+	if err := bindJSON(c, &request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := uc.userService.AssignRole(c.Request.Context(), userID, request.Role); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role assigned successfully"})
+}
+
+// RemoveRole takes a role away from a user
+func (uc *UserController) RemoveRole(c *gin.Context) {
+	userID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidUserID)
+		return
+	}
+
+	if err := uc.userService.RemoveRole(c.Request.Context(), userID, c.Param("role")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role removed successfully"})
+}
+
+// UnlockUser clears a sign-in lockout on a user's account
+func (uc *UserController) UnlockUser(c *gin.Context) {
+	actorID := c.MustGet("userID").(uuid.UUID)
+
+	userID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidUserID)
+		return
+	}
+
+	if err := uc.userService.UnlockUser(c.Request.Context(), actorID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unlocked successfully"})
+}
+
+// ListDeletedUsers lists soft-deleted users for administrators
+func (uc *UserController) ListDeletedUsers(c *gin.Context) {
+	users, err := uc.userService.ListDeletedUsers(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]AdminUserResponse, 0, len(users))
+	for _, user := range users {
+		response = append(response, newAdminUserResponse(user))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": response})
+}
+
+// RestoreUser undoes the soft delete of a user
+func (uc *UserController) RestoreUser(c *gin.Context) {
+	userID, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidUserID)
+		return
+	}
+
+	if err := uc.userService.RestoreUser(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User restored successfully"})
 }
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/service"
+	"github.com/gofrs/uuid"
+)
+
+// RegisterUserRequest is the body accepted by user registration
+type RegisterUserRequest struct {
+	Username  string `json:"username" binding:"required,min=3,max=255"`
+	Email     string `json:"email" binding:"required,email,max=255"`
+	Password  string `json:"password" binding:"required,password"`
+	FirstName string `json:"first_name" binding:"max=255"`
+	LastName  string `json:"last_name" binding:"max=255"`
+}
+
+// AuthenticateUserRequest is the body accepted by sign-in
+type AuthenticateUserRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// UpdateUserRequest is the body accepted when editing a user.
+// Omitted fields are left unchanged; IsActive is only honoured for administrators.
+type UpdateUserRequest struct {
+	Username  *string `json:"username" binding:"omitempty,min=3,max=255"`
+	Email     *string `json:"email" binding:"omitempty,email,max=255"`
+	FirstName *string `json:"first_name" binding:"omitempty,max=255"`
+	LastName  *string `json:"last_name" binding:"omitempty,max=255"`
+	IsActive  *bool   `json:"is_active"`
+}
+
+// apply copies the fields that were sent onto the user
+func (r *UpdateUserRequest) apply(user *entity.User, admin bool) {
+	if r.Username != nil {
+		user.Username = *r.Username
+	}
+	if r.Email != nil {
+		user.Email = *r.Email
+	}
+	if r.FirstName != nil {
+		user.FirstName = *r.FirstName
+	}
+	if r.LastName != nil {
+		user.LastName = *r.LastName
+	}
+	if r.IsActive != nil && admin {
+		user.IsActive = *r.IsActive
+	}
+}
+
+// UserProfileResponse is the public view of a user
+type UserProfileResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Username      string    `json:"username"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// AdminUserResponse is the view of a user shown to administrators
+type AdminUserResponse struct {
+	UserProfileResponse
+	IsActive        bool       `json:"is_active"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+}
+
+// LoginResponse is returned when sign-in or a token refresh succeeds
+type LoginResponse struct {
+	AccessToken      string              `json:"access_token"`
+	TokenType        string              `json:"token_type"`
+	ExpiresAt        time.Time           `json:"expires_at"`
+	ExpiresIn        int64               `json:"expires_in"`
+	RefreshToken     string              `json:"refresh_token"`
+	RefreshExpiresAt time.Time           `json:"refresh_expires_at"`
+	User             UserProfileResponse `json:"user"`
+}
+
+func newUserProfileResponse(user *entity.User) UserProfileResponse {
+	return UserProfileResponse{
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt,
+	}
+}
+
+func newAdminUserResponse(user *entity.User) AdminUserResponse {
+	return AdminUserResponse{
+		UserProfileResponse: newUserProfileResponse(user),
+		IsActive:            user.IsActive,
+		EmailVerifiedAt:     user.EmailVerifiedAt,
+		UpdatedAt:           user.UpdatedAt,
+		DeletedAt:           user.DeletedAt,
+	}
+}
+
+// newUserResponse picks the admin view when the caller may read any user
+func newUserResponse(user *entity.User, admin bool) any {
+	if admin {
+		return newAdminUserResponse(user)
+	}
+	return newUserProfileResponse(user)
+}
+
+func newLoginResponse(result *service.LoginResult) LoginResponse {
+	return LoginResponse{
+		AccessToken:      result.AccessToken,
+		TokenType:        result.TokenType,
+		ExpiresAt:        result.ExpiresAt,
+		ExpiresIn:        result.ExpiresIn,
+		RefreshToken:     result.RefreshToken,
+		RefreshExpiresAt: result.RefreshExpiresAt,
+		User:             newUserProfileResponse(result.User),
+	}
+}
@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Password policy. bcrypt ignores everything after 72 bytes, so longer
+// passwords would only look stronger than they are.
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+// maxEventCapacity bounds how many seats an event may offer
+const maxEventCapacity = 100000
+
+// eventStatuses are the statuses an event can be given in a request
+var eventStatuses = map[string]bool{
+	entity.EventStatusDraft:              true,
+	entity.EventStatusPublished:          true,
+	entity.EventStatusRegistrationOpen:   true,
+	entity.EventStatusRegistrationClosed: true,
+	entity.EventStatusOngoing:            true,
+	entity.EventStatusCompleted:          true,
+	entity.EventStatusCancelled:          true,
+	entity.EventStatusArchived:           true,
+}
+
+// RegisterValidations adds the custom rules used by the request DTOs to gin's
+// validator and makes it report fields by their request names. It must run
+// before any request is bound.
+func RegisterValidations() error {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("gin is not using go-playground/validator")
+	}
+
+	engine.RegisterTagNameFunc(tagName)
+
+	rules := map[string]validator.Func{
+		"password":    validatePassword,
+		"capacity":    validateCapacity,
+		"timezone":    validateTimeZone,
+		"eventstatus": validateEventStatus,
+	}
+	for tag, rule := range rules {
+		if err := engine.RegisterValidation(tag, rule); err != nil {
+			return fmt.Errorf("failed to register %s validation: %v", tag, err)
+		}
+	}
+	return nil
+}
+
+// validatePassword requires an upper case letter, a lower case letter and a
+// digit within the allowed length
+func validatePassword(fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return false
+	}
+
+	var upper, lower, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return upper && lower && digit
+}
+
+// validateCapacity keeps an event's capacity between 1 and maxEventCapacity
+func validateCapacity(fl validator.FieldLevel) bool {
+	capacity := fl.Field().Int()
+	return capacity >= 1 && capacity <= maxEventCapacity
+}
+
+// validateTimeZone accepts IANA time zone names such as Africa/Mogadishu
+func validateTimeZone(fl validator.FieldLevel) bool {
+	_, err := time.LoadLocation(fl.Field().String())
+	return err == nil
+}
+
+// validateEventStatus accepts the known event statuses
+func validateEventStatus(fl validator.FieldLevel) bool {
+	return eventStatuses[fl.Field().String()]
+}
+
+// bindJSON binds the request body to obj. A body that is not valid JSON is a
+// BadRequest error; one that breaks a rule of obj is a Validation error
+// listing every invalid field.
+func bindJSON(c *gin.Context, obj any) error {
+	return bindingError(obj, c.ShouldBindJSON(obj), invalidRequest)
+}
+
+// bindQuery binds the query string to obj, reporting errors like bindJSON
+func bindQuery(c *gin.Context, obj any) error {
+	return bindingError(obj, c.ShouldBindQuery(obj), invalidQuery)
+}
+
+// bindingError turns the error of binding obj into a Validation error listing
+// every invalid field, or into the error malformed builds when the input
+// could not be parsed at all
+func bindingError(obj any, err error, malformed func(error) error) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]apperror.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, fieldError(obj, fe))
+		}
+		return apperror.NewInvalidFields(fields)
+	}
+
+	// A value of the wrong JSON type is reported against its field too
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apperror.NewInvalidFields([]apperror.FieldError{{
+			Field:  typeErr.Field,
+			Code:   "invalid_type",
+			Params: []string{jsonTypeName(typeErr.Type)},
+		}})
+	}
+
+	return malformed(err)
+}
+
+// fieldError turns a failed rule on obj into a stable code and the values
+// its message needs besides the field name
+func fieldError(obj any, fe validator.FieldError) apperror.FieldError {
+	isString := fe.Kind() == reflect.String
+
+	var code string
+	var params []string
+	switch fe.Tag() {
+	case "required":
+		code = "required"
+	case "email":
+		code = "invalid_email"
+	case "min", "gte":
+		code, params = "too_small", []string{fe.Param()}
+		if isString {
+			code = "too_short"
+		}
+	case "max", "lte":
+		code, params = "too_large", []string{fe.Param()}
+		if isString {
+			code = "too_long"
+		}
+	case "gtfield":
+		code, params = "must_be_after", []string{fieldName(obj, fe.Param())}
+	case "password":
+		code, params = "weak_password", []string{strconv.Itoa(minPasswordLength), strconv.Itoa(maxPasswordLength)}
+	case "capacity":
+		code, params = "out_of_range", []string{"1", strconv.Itoa(maxEventCapacity)}
+	case "timezone":
+		code = "invalid_time_zone"
+	case "eventstatus", "oneof":
+		code = "invalid_choice"
+	case "uuid":
+		code = "invalid_uuid"
+	default:
+		code = fe.Tag()
+	}
+
+	return apperror.FieldError{Field: fe.Field(), Code: code, Params: params}
+}
+
+// tagName returns the name a field goes by in requests: its JSON name for
+// bodies or its form name for query strings
+func tagName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// fieldName returns the request name of a field of the struct obj points
+// to, falling back to its Go name
+func fieldName(obj any, name string) string {
+	structType := reflect.TypeOf(obj)
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if field, ok := structType.FieldByName(name); ok {
+		if tag := tagName(field); tag != "" {
+			return tag
+		}
+	}
+	return name
+}
+
+// jsonTypeName names the JSON value a Go type is decoded from
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+// auditRepositoryImpl is the implementation of AuditRepository.
+type auditRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewAuditRepository creates a new instance of AuditRepository.
+func NewAuditRepository(db *sql.DB) repository.AuditRepository {
+	return &auditRepositoryImpl{db: db}
+}
+
+// Create implements repository.AuditRepository.
+func (a *auditRepositoryImpl) Create(ctx context.Context, event *entity.AuditEvent) error {
+	if event.ID == uuid.Nil {
+		id, err := uuid.NewV4()
+		if err != nil {
+			logger.FromContext(ctx).Error("Error generating UUID", "error", err)
+			return err
+		}
+		event.ID = id
+	}
+	event.CreatedAt = time.Now().UTC()
+
+	query := `INSERT INTO audit_events (id, type, user_id, actor_id, ip_address, detail, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := conn(ctx, a.db).ExecContext(ctx, query, event.ID, event.Type, event.UserID, event.ActorID, event.IPAddress, event.Detail, event.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error inserting audit event", "type", event.Type, "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -1,12 +1,17 @@
 package gateway
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"strings"
+	"time"
+	"unicode"
 
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
 	"github.com/gofrs/uuid"
 )
 
@@ -15,122 +20,268 @@ type EventRepositoryimpl struct {
 }
 
 // Create implements repository.EventRepository.
-func (e *EventRepositoryimpl) Create(event *entity.Event) error {
+func (e *EventRepositoryimpl) Create(ctx context.Context, event *entity.Event) error {
 
-	log.Printf("Inserting into events: %+v", event)
+	logger.FromContext(ctx).Info("Inserting event", "event_id", event.ID, "organizer_id", event.OrganizerID)
 
 	query := `INSERT INTO events (
-		id, title, description, location, start_time, end_time,
+		id, title, description, location, start_time, end_time, time_zone,
 		capacity, is_public, status, organizer_id, created_at, updated_at
 	) VALUES (
-		$1, $2, $3, $4, $5, $6,
-		$7, $8, $9, $10, $11, $12
+		$1, $2, $3, $4, $5, $6, $7,
+		$8, $9, $10, $11, $12, $13
 	)`
 
-	result, err := e.db.Exec(query,
+	result, err := conn(ctx, e.db).ExecContext(ctx, query,
 		event.ID, event.Title, event.Description, event.Location,
-		event.StartTime, event.EndTime, event.Capacity, event.IsPublic,
+		event.StartTime.UTC(), event.EndTime.UTC(), event.TimeZone, event.Capacity, event.IsPublic,
 		event.Status, event.OrganizerID, event.CreatedAt, event.UpdatedAt,
 	)
 
 	if err != nil {
-		log.Printf("Error inserting event: %v\nQuery: %s", err, query)
+		logger.FromContext(ctx).Error("Error inserting event", "error", err, "query", query)
 		return err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		log.Printf("Error fetching rows affected: %v", err)
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
 		return err
 	}
 
-	log.Printf("Rows affected: %d", rowsAffected)
+	logger.FromContext(ctx).Info("Rows affected", "rows_affected", rowsAffected)
 	return nil
 }
 
 // Delete implements repository.EventRepository.
-func (e *EventRepositoryimpl) Delete(eventID uuid.UUID) error {
+func (e *EventRepositoryimpl) Delete(ctx context.Context, eventID uuid.UUID) error {
 
-	query := `DELETE FROM events WHERE id = $1`
-	result, err := e.db.Exec(query, eventID)
+	// Soft delete: the row is kept until the purge job removes it
+	query := `UPDATE events SET deleted_at = $2, updated_at = $2 WHERE id = $1 AND deleted_at IS NULL`
+	result, err := conn(ctx, e.db).ExecContext(ctx, query, eventID, time.Now())
 	if err != nil {
-		log.Printf("Error deleting event with ID %v: %v", eventID, err)
+		logger.FromContext(ctx).Error("Error deleting event", "event_id", eventID, "error", err)
 		return err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		log.Printf("Error fetching rows affected: %v", err)
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
 		return err
 	}
 
 	if rowsAffected == 0 {
-		log.Printf("No event found with ID: %v", eventID)
-		return nil
+		logger.FromContext(ctx).Warn("No event found", "event_id", eventID)
+		return repository.ErrEventNotFound
 	}
 
-	log.Printf("Deleted event with ID: %v", eventID)
+	logger.FromContext(ctx).Info("Deleted event", "event_id", eventID)
 	return nil
 }
 
-// GetAll implements repository.EventRepository.
-func (e *EventRepositoryimpl) GetAll() ([]*entity.Event, error) {
+// eventSortColumns maps each listing order to the column it sorts by. Each
+// has an index on (column, id) for live events.
+var eventSortColumns = map[string]string{
+	repository.EventSortStartTime: "start_time",
+	repository.EventSortCreatedAt: "created_at",
+	repository.EventSortTitle:     "title",
+}
 
-	var events []*entity.Event
+// List implements repository.EventRepository. Pages are keyset paginated:
+// each continues after the (sort value, id) of the previous page's last
+// event, so deep pages cost no more than the first.
+func (e *EventRepositoryimpl) List(ctx context.Context, filter repository.EventFilter) (*repository.EventPage, error) {
+	column, ok := eventSortColumns[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown event sort %q", filter.Sort)
+	}
+
+	where, args := eventFilterClause(filter)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM events WHERE ` + where
+	if err := conn(ctx, e.db).QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		logger.FromContext(ctx).Error("Error counting events", "error", err)
+		return nil, err
+	}
 
-	query := `SELECT id, title, description, location, start_time, end_time,
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.After.Value, filter.After.ID)
+		where += fmt.Sprintf(" AND (%s, id) %s ($%d, $%d)", column, comparison, len(args)-1, len(args))
+	}
+
+	// One extra row tells whether there is another page
+	args = append(args, filter.Limit+1)
+	query := fmt.Sprintf(`SELECT id, title, description, location, start_time, end_time, time_zone,
 		capacity, is_public, status, organizer_id, created_at, updated_at, deleted_at
-		FROM events`
+		FROM events WHERE %s ORDER BY %s %s, id %s LIMIT $%d`, where, column, direction, direction, len(args))
 
-	rows, err := e.db.Query(query)
+	rows, err := conn(ctx, e.db).QueryContext(ctx, query, args...)
 	if err != nil {
-		log.Printf("Error retrieving events: %v", err)
+		logger.FromContext(ctx).Error("Error retrieving events", "error", err)
 		return nil, err
 	}
 	defer rows.Close()
 
+	events := make([]*entity.Event, 0, filter.Limit+1)
 	for rows.Next() {
 		var event entity.Event
 		err := rows.Scan(
 			&event.ID, &event.Title, &event.Description, &event.Location,
-			&event.StartTime, &event.EndTime, &event.Capacity, &event.IsPublic,
+			&event.StartTime, &event.EndTime, &event.TimeZone, &event.Capacity, &event.IsPublic,
 			&event.Status, &event.OrganizerID, &event.CreatedAt, &event.UpdatedAt, &event.DeletedAt,
 		)
 		if err != nil {
-			log.Printf("Error scanning event: %v", err)
+			logger.FromContext(ctx).Error("Error scanning event", "error", err)
 			return nil, err
 		}
 		events = append(events, &event)
 	}
 
 	if err = rows.Err(); err != nil {
-		log.Printf("Error iterating events: %v", err)
+		logger.FromContext(ctx).Error("Error iterating events", "error", err)
 		return nil, err
 	}
 
-	return events, nil
+	page := &repository.EventPage{Events: events, Total: total}
+	if len(events) > filter.Limit {
+		page.Events = events[:filter.Limit]
+		last := page.Events[filter.Limit-1]
+		page.Next = &repository.EventCursor{Value: eventSortValue(last, filter.Sort), ID: last.ID}
+	}
+
+	return page, nil
+}
+
+// eventFilterClause builds the WHERE clause selecting the live events that
+// match filter, and its arguments
+func eventFilterClause(filter repository.EventFilter) (string, []any) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []any
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.OrganizerID != uuid.Nil {
+		add("organizer_id = $%d", filter.OrganizerID)
+	}
+	if filter.Location != "" {
+		add("location ILIKE $%d", "%"+escapeLike(filter.Location)+"%")
+	}
+	if filter.From != nil {
+		add("COALESCE(end_time, start_time) >= $%d", filter.From.UTC())
+	}
+	if filter.To != nil {
+		add("start_time <= $%d", filter.To.UTC())
+	}
+	if filter.IsPublic != nil {
+		add("is_public = $%d", *filter.IsPublic)
+	}
+	if search := titleQuery(filter.Search); search != "" {
+		add("to_tsvector('simple', title) @@ to_tsquery('simple', $%d)", search)
+	}
+
+	// Private and draft events are only listed for the people running them
+	if !filter.ViewAll {
+		var visible string
+		visible, args = eventVisibility(filter.ViewerID, args)
+		conditions = append(conditions, visible)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// eventVisibility returns the condition limiting events to those viewerID
+// may see: public events that are not drafts, and the events they organize
+// or co-organize. Its arguments are appended to args.
+func eventVisibility(viewerID uuid.UUID, args []any) (string, []any) {
+	args = append(args, entity.EventStatusDraft, viewerID)
+	condition := fmt.Sprintf(`(
+			(is_public AND status <> $%[1]d)
+			OR organizer_id = $%[2]d
+			OR EXISTS (SELECT 1 FROM event_organizers o WHERE o.event_id = events.id AND o.user_id = $%[2]d)
+		)`, len(args)-1, len(args))
+	return condition, args
+}
+
+// eventSortValue returns the value event is sorted by in a listing
+func eventSortValue(event *entity.Event, sort string) any {
+	switch sort {
+	case repository.EventSortCreatedAt:
+		return event.CreatedAt
+	case repository.EventSortTitle:
+		return event.Title
+	default:
+		return event.StartTime
+	}
+}
+
+// titleQuery turns free text into a tsquery matching titles that contain
+// every word as a prefix, or "" when the text has no words. Only letters
+// and digits are kept, so the text cannot inject tsquery operators.
+func titleQuery(search string) string {
+	words := strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 // GetdByID implements repository.EventRepository.
-func (e *EventRepositoryimpl) GetByID(eventID uuid.UUID) (*entity.Event, error) {
-	var event entity.Event
+func (e *EventRepositoryimpl) GetByID(ctx context.Context, eventID uuid.UUID) (*entity.Event, error) {
+	return e.findByID(ctx, eventSelectByID, eventID)
+}
+
+// LockByID implements repository.EventRepository.
+func (e *EventRepositoryimpl) LockByID(ctx context.Context, eventID uuid.UUID) (*entity.Event, error) {
+	return e.findByID(ctx, eventSelectByID+` FOR UPDATE`, eventID)
+}
+
+// GetVisibleByID implements repository.EventRepository.
+func (e *EventRepositoryimpl) GetVisibleByID(ctx context.Context, eventID, viewerID uuid.UUID) (*entity.Event, error) {
+	// The event ID is $1, so the visibility arguments follow it
+	visible, args := eventVisibility(viewerID, []any{eventID})
+	return e.findByID(ctx, eventSelectByID+` AND `+visible, eventID, args[1:]...)
+}
 
-	query := `SELECT id, title, description, location, start_time, end_time,
+// eventSelectByID selects a single event by its ID ($1)
+const eventSelectByID = `SELECT id, title, description, location, start_time, end_time, time_zone,
 		capacity, is_public, status, organizer_id, created_at, updated_at, deleted_at
-		FROM events WHERE id = $1`
+		FROM events WHERE id = $1 AND deleted_at IS NULL`
+
+// findByID runs a single-event query and scans its row. The event ID is $1;
+// args fill any later placeholders.
+func (e *EventRepositoryimpl) findByID(ctx context.Context, query string, eventID uuid.UUID, args ...any) (*entity.Event, error) {
+	var event entity.Event
 
-	err := e.db.QueryRow(query, eventID).Scan(
+	err := conn(ctx, e.db).QueryRowContext(ctx, query, append([]any{eventID}, args...)...).Scan(
 		&event.ID, &event.Title, &event.Description, &event.Location,
-		&event.StartTime, &event.EndTime, &event.Capacity, &event.IsPublic,
+		&event.StartTime, &event.EndTime, &event.TimeZone, &event.Capacity, &event.IsPublic,
 		&event.Status, &event.OrganizerID, &event.CreatedAt, &event.UpdatedAt, &event.DeletedAt,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Printf("No event found with ID: %v", eventID)
-			return nil, fmt.Errorf("event not found")
+			logger.FromContext(ctx).Warn("No event found", "event_id", eventID)
+			return nil, repository.ErrEventNotFound
 		}
-		log.Printf("Error retrieving event by ID: %v", err)
+		logger.FromContext(ctx).Error("Error retrieving event", "error", err)
 		return nil, err
 	}
 
@@ -138,38 +289,201 @@ func (e *EventRepositoryimpl) GetByID(eventID uuid.UUID) (*entity.Event, error)
 }
 
 // Update implements repository.EventRepository.
-func (e *EventRepositoryimpl) Update(event *entity.Event) error {
+func (e *EventRepositoryimpl) Update(ctx context.Context, event *entity.Event) error {
 	query := `UPDATE events
 		SET title = $2, description = $3, location = $4, start_time = $5,
-			end_time = $6, capacity = $7, is_public = $8, status = $9,
-			organizer_id = $10, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1`
+			end_time = $6, time_zone = $7, capacity = $8, is_public = $9, status = $10,
+			organizer_id = $11, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := e.db.Exec(query,
+	result, err := conn(ctx, e.db).ExecContext(ctx, query,
 		event.ID, event.Title, event.Description, event.Location,
-		event.StartTime, event.EndTime, event.Capacity, event.IsPublic,
+		event.StartTime.UTC(), event.EndTime.UTC(), event.TimeZone, event.Capacity, event.IsPublic,
 		event.Status, event.OrganizerID,
 	)
 	if err != nil {
-		log.Printf("Error updating event with ID %v: %v", event.ID, err)
+		logger.FromContext(ctx).Error("Error updating event", "event_id", event.ID, "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		logger.FromContext(ctx).Warn("No event found", "event_id", event.ID)
+		return repository.ErrEventNotFound
+	}
+
+	logger.FromContext(ctx).Info("Event updated successfully", "event_id", event.ID)
+	return nil
+}
+
+// UpdateStatus implements repository.EventRepository.
+func (e *EventRepositoryimpl) UpdateStatus(ctx context.Context, eventID uuid.UUID, from, to string) error {
+	query := `UPDATE events SET status = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $2 AND deleted_at IS NULL`
+
+	result, err := conn(ctx, e.db).ExecContext(ctx, query, eventID, from, to)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error updating event status", "event_id", eventID, "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrStaleEventStatus
+	}
+
+	logger.FromContext(ctx).Info("Event status changed", "event_id", eventID, "from", from, "to", to)
+	return nil
+}
+
+// ListCoOrganizers implements repository.EventRepository.
+func (e *EventRepositoryimpl) ListCoOrganizers(ctx context.Context, eventID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := conn(ctx, e.db).QueryContext(ctx, `SELECT user_id FROM event_organizers WHERE event_id = $1`, eventID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error retrieving co-organizers", "event_id", eventID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			logger.FromContext(ctx).Error("Error scanning co-organizer", "error", err)
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("Error iterating co-organizers", "error", err)
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+// AddCoOrganizer implements repository.EventRepository.
+func (e *EventRepositoryimpl) AddCoOrganizer(ctx context.Context, eventID, userID uuid.UUID) error {
+	query := `INSERT INTO event_organizers (event_id, user_id) VALUES ($1, $2)
+		ON CONFLICT (event_id, user_id) DO NOTHING`
+
+	if _, err := conn(ctx, e.db).ExecContext(ctx, query, eventID, userID); err != nil {
+		if isPQError(err, foreignKeyViolation) {
+			return repository.ErrUserNotFound
+		}
+		logger.FromContext(ctx).Error("Error adding co-organizer", "user_id", userID, "event_id", eventID, "error", err)
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Added co-organizer", "user_id", userID, "event_id", eventID)
+	return nil
+}
+
+// RemoveCoOrganizer implements repository.EventRepository.
+func (e *EventRepositoryimpl) RemoveCoOrganizer(ctx context.Context, eventID, userID uuid.UUID) error {
+	result, err := conn(ctx, e.db).ExecContext(ctx, `DELETE FROM event_organizers WHERE event_id = $1 AND user_id = $2`, eventID, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error removing co-organizer", "user_id", userID, "event_id", eventID, "error", err)
 		return err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		log.Printf("Error fetching rows affected: %v", err)
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
 		return err
 	}
 
 	if rowsAffected == 0 {
-		log.Printf("No event found with ID: %v", event.ID)
-		return nil
+		return apperror.NewNotFound("user is not a co-organizer of this event")
 	}
 
-	log.Printf("Event updated successfully: %v", event.ID)
+	logger.FromContext(ctx).Info("Removed co-organizer", "user_id", userID, "event_id", eventID)
 	return nil
 }
 
+// ListDeleted implements repository.EventRepository.
+func (e *EventRepositoryimpl) ListDeleted(ctx context.Context) ([]*entity.Event, error) {
+	var events []*entity.Event
+
+	query := `SELECT id, title, description, location, start_time, end_time, time_zone,
+		capacity, is_public, status, organizer_id, created_at, updated_at, deleted_at
+		FROM events WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`
+
+	rows, err := conn(ctx, e.db).QueryContext(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error retrieving deleted events", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event entity.Event
+		err := rows.Scan(
+			&event.ID, &event.Title, &event.Description, &event.Location,
+			&event.StartTime, &event.EndTime, &event.TimeZone, &event.Capacity, &event.IsPublic,
+			&event.Status, &event.OrganizerID, &event.CreatedAt, &event.UpdatedAt, &event.DeletedAt,
+		)
+		if err != nil {
+			logger.FromContext(ctx).Error("Error scanning event", "error", err)
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("Error iterating events", "error", err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Restore implements repository.EventRepository.
+func (e *EventRepositoryimpl) Restore(ctx context.Context, eventID uuid.UUID) error {
+	query := `UPDATE events SET deleted_at = NULL, updated_at = $2 WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := conn(ctx, e.db).ExecContext(ctx, query, eventID, time.Now())
+	if err != nil {
+		logger.FromContext(ctx).Error("Error restoring event", "event_id", eventID, "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrEventNotDeleted
+	}
+
+	logger.FromContext(ctx).Info("Restored event", "event_id", eventID)
+	return nil
+}
+
+// PurgeDeleted implements repository.EventRepository.
+func (e *EventRepositoryimpl) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, e.db).ExecContext(ctx, `DELETE FROM events WHERE deleted_at < $1`, before)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error purging deleted events", "error", err)
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 // factory function to create an instance of EventRepository
 func NewEventRepository(db *sql.DB) repository.EventRepository {
 	return &EventRepositoryimpl{db: db}
@@ -0,0 +1,73 @@
+package gateway
+
+import (
+	"strings"
+	"testing"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"github.com/gofrs/uuid"
+)
+
+func TestTitleQuery(t *testing.T) {
+	tests := []struct {
+		name, search, want string
+	}{
+		{"single word", "conference", "conference:*"},
+		{"several words", "Go  conference 2025", "Go:* & conference:* & 2025:*"},
+		{"tsquery operators", "go & !conf | (meetup) <-> x:*", "go:* & conf:* & meetup:* & x:*"},
+		{"quotes and backslashes", `o'brien \ "talk"`, "o:* & brien:* & talk:*"},
+		{"non-latin letters", "شير بيشا", "شير:* & بيشا:*"},
+		{"combining marks", "cafe\u0301", "cafe\u0301:*"},
+		{"no words", " !&|:*() ", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := titleQuery(tt.search); got != tt.want {
+				t.Errorf("titleQuery(%q) = %q, want %q", tt.search, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	if got, want := escapeLike(`50%_off\now`), `50\%\_off\\now`; got != want {
+		t.Errorf("escapeLike = %q, want %q", got, want)
+	}
+}
+
+func TestEventFilterClauseVisibility(t *testing.T) {
+	viewer := uuid.Must(uuid.NewV4())
+
+	where, args := eventFilterClause(repository.EventFilter{ViewerID: viewer})
+	if !strings.Contains(where, "is_public") || !strings.Contains(where, "event_organizers") {
+		t.Errorf("listing for a user is not limited to visible events: %s", where)
+	}
+	if len(args) != 2 || args[1] != viewer {
+		t.Errorf("args = %v, want the draft status and the viewer", args)
+	}
+
+	where, args = eventFilterClause(repository.EventFilter{ViewerID: viewer, ViewAll: true})
+	if where != "deleted_at IS NULL" || len(args) != 0 {
+		t.Errorf("listing for an administrator is limited: %s %v", where, args)
+	}
+}
+
+func TestEventVisibilityByID(t *testing.T) {
+	eventID := uuid.Must(uuid.NewV4())
+	viewer := uuid.Must(uuid.NewV4())
+
+	// GetVisibleByID binds the event ID to $1, so the rule must start at $2
+	condition, args := eventVisibility(viewer, []any{eventID})
+	for _, placeholder := range []string{"status <> $2", "organizer_id = $3", "o.user_id = $3"} {
+		if !strings.Contains(condition, placeholder) {
+			t.Errorf("condition does not contain %q: %s", placeholder, condition)
+		}
+	}
+	if strings.Contains(condition, "$1") {
+		t.Errorf("condition reuses the event ID placeholder: %s", condition)
+	}
+	if len(args) != 3 || args[0] != eventID || args[2] != viewer {
+		t.Errorf("args = %v, want the event ID, the draft status and the viewer", args)
+	}
+}
@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+)
+
+// loginAttemptRepositoryImpl is the Postgres implementation of LoginAttemptRepository.
+// It is shared by every instance of the server.
+type loginAttemptRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository.
+func NewLoginAttemptRepository(db *sql.DB) repository.LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{db: db}
+}
+
+// Get implements repository.LoginAttemptRepository.
+func (l *loginAttemptRepositoryImpl) Get(ctx context.Context, key string) (*entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+
+	err := conn(ctx, l.db).QueryRowContext(ctx, `SELECT key, failures, last_failure_at, locked_until FROM login_attempts WHERE key = $1`, key).Scan(
+		&attempt.Key, &attempt.Failures, &attempt.LastFailureAt, &attempt.LockedUntil,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		logger.FromContext(ctx).Error("Error retrieving login attempts", "error", err)
+		return nil, err
+	}
+
+	return &attempt, nil
+}
+
+// RecordFailure implements repository.LoginAttemptRepository.
+func (l *loginAttemptRepositoryImpl) RecordFailure(ctx context.Context, key string, resetAfter time.Duration) (int, error) {
+	query := `INSERT INTO login_attempts (key, failures, last_failure_at) VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE
+		SET failures = CASE WHEN login_attempts.last_failure_at < $3 THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING failures`
+
+	now := time.Now().UTC()
+
+	var failures int
+	if err := conn(ctx, l.db).QueryRowContext(ctx, query, key, now, now.Add(-resetAfter)).Scan(&failures); err != nil {
+		logger.FromContext(ctx).Error("Error recording failed login", "error", err)
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+// Lock implements repository.LoginAttemptRepository.
+func (l *loginAttemptRepositoryImpl) Lock(ctx context.Context, key string, until time.Time) error {
+	if _, err := conn(ctx, l.db).ExecContext(ctx, `UPDATE login_attempts SET locked_until = $2 WHERE key = $1`, key, until.UTC()); err != nil {
+		logger.FromContext(ctx).Error("Error locking login key", "error", err)
+		return err
+	}
+	return nil
+}
+
+// Reset implements repository.LoginAttemptRepository.
+func (l *loginAttemptRepositoryImpl) Reset(ctx context.Context, key string) error {
+	if _, err := conn(ctx, l.db).ExecContext(ctx, `DELETE FROM login_attempts WHERE key = $1`, key); err != nil {
+		logger.FromContext(ctx).Error("Error resetting login attempts", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+)
+
+// memoryLoginAttemptRepository keeps login attempts in process memory.
+// Counts are lost on restart and not shared between instances.
+type memoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]*entity.LoginAttempt
+}
+
+// NewMemoryLoginAttemptRepository creates an in-memory LoginAttemptRepository.
+func NewMemoryLoginAttemptRepository() repository.LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{attempts: map[string]*entity.LoginAttempt{}}
+}
+
+// Get implements repository.LoginAttemptRepository.
+func (m *memoryLoginAttemptRepository) Get(ctx context.Context, key string) (*entity.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempt, ok := m.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *attempt
+	return &copied, nil
+}
+
+// RecordFailure implements repository.LoginAttemptRepository.
+func (m *memoryLoginAttemptRepository) RecordFailure(ctx context.Context, key string, resetAfter time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	attempt, ok := m.attempts[key]
+	if !ok {
+		attempt = &entity.LoginAttempt{Key: key}
+		m.attempts[key] = attempt
+	}
+	if attempt.LastFailureAt.Before(now.Add(-resetAfter)) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+
+	return attempt.Failures, nil
+}
+
+// Lock implements repository.LoginAttemptRepository.
+func (m *memoryLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if attempt, ok := m.attempts[key]; ok {
+		until = until.UTC()
+		attempt.LockedUntil = &until
+	}
+	return nil
+}
+
+// Reset implements repository.LoginAttemptRepository.
+func (m *memoryLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.attempts, key)
+	return nil
+}
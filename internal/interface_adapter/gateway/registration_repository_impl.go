@@ -0,0 +1,180 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+// registrationsWithPosition selects an event's registrations ($1) along with
+// their 1-based waitlist position; rows not in the waitlisted status ($2) get 0.
+const registrationsWithPosition = `SELECT id, event_id, user_id, status, created_at, updated_at, deleted_at,
+		CASE WHEN status = $2
+			THEN ROW_NUMBER() OVER (PARTITION BY status ORDER BY created_at, id)
+			ELSE 0
+		END AS position
+	FROM registrations WHERE event_id = $1`
+
+// registrationRepositoryImpl is the implementation of RegistrationRepository.
+type registrationRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewRegistrationRepository creates a new instance of RegistrationRepository.
+func NewRegistrationRepository(db *sql.DB) repository.RegistrationRepository {
+	return &registrationRepositoryImpl{db: db}
+}
+
+// Create implements repository.RegistrationRepository.
+func (r *registrationRepositoryImpl) Create(ctx context.Context, registration *entity.Registration) error {
+	query := `INSERT INTO registrations (id, event_id, user_id, status, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5, $6)
+	          ON CONFLICT (event_id, user_id) DO NOTHING`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		registration.ID, registration.EventID, registration.UserID,
+		registration.Status, registration.CreatedAt, registration.UpdatedAt,
+	)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error inserting registration", "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrAlreadyRegistered
+	}
+
+	return nil
+}
+
+// Delete implements repository.RegistrationRepository.
+func (r *registrationRepositoryImpl) Delete(ctx context.Context, eventID, userID uuid.UUID) (*entity.Registration, error) {
+	var registration entity.Registration
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, `DELETE FROM registrations WHERE event_id = $1 AND user_id = $2
+		RETURNING id, event_id, user_id, status, created_at, updated_at, deleted_at`,
+		eventID, userID,
+	).Scan(
+		&registration.ID, &registration.EventID, &registration.UserID, &registration.Status,
+		&registration.CreatedAt, &registration.UpdatedAt, &registration.DeletedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrRegistrationNotFound
+		}
+		logger.FromContext(ctx).Error("Error deleting registration", "event_id", eventID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("Deleted registration", "event_id", eventID, "user_id", userID)
+	return &registration, nil
+}
+
+// CountByStatus implements repository.RegistrationRepository.
+func (r *registrationRepositoryImpl) CountByStatus(ctx context.Context, eventID uuid.UUID, status string) (int, error) {
+	var count int
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = $2`,
+		eventID, status).Scan(&count)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error counting registrations", "event_id", eventID, "error", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// PromoteNext implements repository.RegistrationRepository.
+func (r *registrationRepositoryImpl) PromoteNext(ctx context.Context, eventID uuid.UUID) (*entity.Registration, error) {
+	var registration entity.Registration
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE registrations SET status = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM registrations
+			WHERE event_id = $1 AND status = $3
+			ORDER BY created_at, id
+			LIMIT 1
+		)
+		RETURNING id, event_id, user_id, status, created_at, updated_at, deleted_at`,
+		eventID, entity.RegistrationStatusConfirmed, entity.RegistrationStatusWaitlisted,
+	).Scan(
+		&registration.ID, &registration.EventID, &registration.UserID, &registration.Status,
+		&registration.CreatedAt, &registration.UpdatedAt, &registration.DeletedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		logger.FromContext(ctx).Error("Error promoting waitlisted registration", "event_id", eventID, "error", err)
+		return nil, err
+	}
+
+	return &registration, nil
+}
+
+// FindByEventAndUser implements repository.RegistrationRepository.
+func (r *registrationRepositoryImpl) FindByEventAndUser(ctx context.Context, eventID, userID uuid.UUID) (*entity.Registration, error) {
+	var registration entity.Registration
+
+	query := `SELECT id, event_id, user_id, status, position, created_at, updated_at, deleted_at
+		FROM (` + registrationsWithPosition + `) r WHERE user_id = $3`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, eventID, entity.RegistrationStatusWaitlisted, userID).Scan(
+		&registration.ID, &registration.EventID, &registration.UserID, &registration.Status, &registration.Position,
+		&registration.CreatedAt, &registration.UpdatedAt, &registration.DeletedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrRegistrationNotFound
+		}
+		logger.FromContext(ctx).Error("Error retrieving registration", "error", err)
+		return nil, err
+	}
+
+	return &registration, nil
+}
+
+// ListByEvent implements repository.RegistrationRepository.
+func (r *registrationRepositoryImpl) ListByEvent(ctx context.Context, eventID uuid.UUID) ([]*entity.Registration, error) {
+	var registrations []*entity.Registration
+
+	query := `SELECT id, event_id, user_id, status, position, created_at, updated_at, deleted_at
+		FROM (` + registrationsWithPosition + `) r ORDER BY position, created_at`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, eventID, entity.RegistrationStatusWaitlisted)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error retrieving registrations", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var registration entity.Registration
+		err := rows.Scan(
+			&registration.ID, &registration.EventID, &registration.UserID, &registration.Status, &registration.Position,
+			&registration.CreatedAt, &registration.UpdatedAt, &registration.DeletedAt,
+		)
+		if err != nil {
+			logger.FromContext(ctx).Error("Error scanning registration", "error", err)
+			return nil, err
+		}
+		registrations = append(registrations, &registration)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("Error iterating registrations", "error", err)
+		return nil, err
+	}
+
+	return registrations, nil
+}
@@ -0,0 +1,149 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+// roleRepositoryImpl is the implementation of RoleRepository.
+type roleRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewRoleRepository creates a new instance of RoleRepository.
+func NewRoleRepository(db *sql.DB) repository.RoleRepository {
+	return &roleRepositoryImpl{db: db}
+}
+
+// ListByUser implements repository.RoleRepository.
+func (r *roleRepositoryImpl) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Role, error) {
+	query := `SELECT r.id, r.name FROM roles r
+		JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = $1
+		ORDER BY r.name`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error retrieving roles", "user_id", userID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []*entity.Role
+	for rows.Next() {
+		var role entity.Role
+		if err := rows.Scan(&role.ID, &role.Name); err != nil {
+			logger.FromContext(ctx).Error("Error scanning role", "error", err)
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("Error iterating roles", "error", err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// AssignToUser implements repository.RoleRepository.
+func (r *roleRepositoryImpl) AssignToUser(ctx context.Context, userID uuid.UUID, roleName string) error {
+	var roleID int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT id FROM roles WHERE name = $1`, roleName).Scan(&roleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperror.NewNotFound(`role "{0}" not found`).WithParams(roleName)
+		}
+		logger.FromContext(ctx).Error("Error retrieving role", "role_name", roleName, "error", err)
+		return err
+	}
+
+	query := `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2)
+	          ON CONFLICT (user_id, role_id) DO NOTHING`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, userID, roleID); err != nil {
+		logger.FromContext(ctx).Error("Error assigning role", "role_name", roleName, "user_id", userID, "error", err)
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Assigned role", "role_name", roleName, "user_id", userID)
+	return nil
+}
+
+// RemoveFromUser implements repository.RoleRepository.
+func (r *roleRepositoryImpl) RemoveFromUser(ctx context.Context, userID uuid.UUID, roleName string) error {
+	query := `DELETE FROM user_roles
+		WHERE user_id = $1 AND role_id = (SELECT id FROM roles WHERE name = $2)`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, userID, roleName)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error removing role", "role_name", roleName, "user_id", userID, "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return apperror.NewNotFound(`user does not have role "{0}"`).WithParams(roleName)
+	}
+
+	logger.FromContext(ctx).Info("Removed role", "role_name", roleName, "user_id", userID)
+	return nil
+}
+
+// permissionRepositoryImpl is the implementation of PermissionRepository.
+type permissionRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewPermissionRepository creates a new instance of PermissionRepository.
+func NewPermissionRepository(db *sql.DB) repository.PermissionRepository {
+	return &permissionRepositoryImpl{db: db}
+}
+
+// ListByUser implements repository.PermissionRepository.
+func (p *permissionRepositoryImpl) ListByUser(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	query := `SELECT p.name FROM permissions p
+		JOIN user_permissions up ON up.permission_id = p.id
+		WHERE up.user_id = $1
+		UNION
+		SELECT p.name FROM permissions p
+		JOIN role_permissions rp ON rp.permission_id = p.id
+		JOIN user_roles ur ON ur.role_id = rp.role_id
+		WHERE ur.user_id = $1`
+
+	rows, err := conn(ctx, p.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error retrieving permissions", "user_id", userID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			logger.FromContext(ctx).Error("Error scanning permission", "error", err)
+			return nil, err
+		}
+		permissions = append(permissions, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("Error iterating permissions", "error", err)
+		return nil, err
+	}
+
+	return permissions, nil
+}
@@ -1,34 +1,41 @@
 package gateway
 
 import (
+	"context"
 	"database/sql"
-	"log"
 	"time"
 
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/utils"
+	"github.com/gofrs/uuid"
 )
 
-// tokenRepositoryImpl is the implementation of TokenRepository
+// tokenRepositoryImpl is the implementation of TokenRepository.
+// Only a keyed hash of each token is stored; the plaintext never reaches the database.
 type tokenRepositoryImpl struct {
-	db *sql.DB
+	db      *sql.DB
+	hashKey []byte
 }
 
 // NewTokenRepository creates a new instance of TokenRepository.
-func NewTokenRepository(db *sql.DB) repository.TokenRepository {
-	return &tokenRepositoryImpl{db: db}
+func NewTokenRepository(db *sql.DB, hashKey []byte) repository.TokenRepository {
+	return &tokenRepositoryImpl{db: db, hashKey: hashKey}
 }
 
 // FindByToken implements repository.TokenRepository.
-func (t *tokenRepositoryImpl) FindByToken(token string) (*entity.Token, error) {
+// The returned entity carries the stored hash, not the plaintext token.
+func (t *tokenRepositoryImpl) FindByToken(ctx context.Context, token string) (*entity.Token, error) {
 	r := &entity.Token{}
-	query := `SELECT id, user_id, token, expires_at, created_at, updated_at FROM tokens WHERE token = $1`
-	row := t.db.QueryRow(query, token)
+	query := `SELECT id, user_id, token, type, COALESCE(family_id, id), expires_at, used_at, user_agent, ip_address, created_at, updated_at, deleted_at
+		FROM tokens WHERE token = $1`
+	row := conn(ctx, t.db).QueryRowContext(ctx, query, utils.HashToken(t.hashKey, token))
 
-	err := row.Scan(&r.ID, &r.UserID, &r.Token, &r.ExpiresAt, &r.CreatedAt, &r.UpdatedAt)
+	err := row.Scan(&r.ID, &r.UserID, &r.Token, &r.Type, &r.FamilyID, &r.ExpiresAt, &r.UsedAt, &r.UserAgent, &r.IPAddress, &r.CreatedAt, &r.UpdatedAt, &r.DeletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Printf("token not found")
+			logger.FromContext(ctx).Debug("Token not found")
 			//return nil, errors.New("token not found")
 			return nil, nil
 		}
@@ -37,27 +44,193 @@ func (t *tokenRepositoryImpl) FindByToken(token string) (*entity.Token, error) {
 	return r, err
 }
 
+// FindLatestByUser implements repository.TokenRepository.
+func (t *tokenRepositoryImpl) FindLatestByUser(ctx context.Context, userID uuid.UUID, tokenType string) (*entity.Token, error) {
+	r := &entity.Token{}
+	query := `SELECT id, user_id, token, type, COALESCE(family_id, id), expires_at, used_at, user_agent, ip_address, created_at, updated_at, deleted_at
+		FROM tokens WHERE user_id = $1 AND type = $2
+		ORDER BY created_at DESC LIMIT 1`
+	row := conn(ctx, t.db).QueryRowContext(ctx, query, userID, tokenType)
+
+	err := row.Scan(&r.ID, &r.UserID, &r.Token, &r.Type, &r.FamilyID, &r.ExpiresAt, &r.UsedAt, &r.UserAgent, &r.IPAddress, &r.CreatedAt, &r.UpdatedAt, &r.DeletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		logger.FromContext(ctx).Error("Error retrieving latest token", "token_type", tokenType, "user_id", userID, "error", err)
+		return nil, err
+	}
+	return r, nil
+}
+
 // Create implements repository.TokenRepository.
-func (t *tokenRepositoryImpl) Create(token *entity.Token) error {
+func (t *tokenRepositoryImpl) Create(ctx context.Context, token *entity.Token) error {
 	// ❌ Fix: Column name was "tokens" — should be "token" (as per SELECT query)
-	query := `INSERT INTO tokens (id, user_id, token, expires_at, created_at, updated_at)
-	          VALUES($1, $2, $3, $4, $5, $6)`
+	query := `INSERT INTO tokens (id, user_id, token, token_hashed, type, family_id, expires_at, user_agent, ip_address, created_at, updated_at)
+	          VALUES($1, $2, $3, TRUE, $4, $5, $6, $7, $8, $9, $10)`
 
 	// ✅ Use time.Now().UTC() for consistency
 	now := time.Now().UTC()
 
-	result, err := t.db.Exec(query, token.ID, token.UserID, token.Token, token.ExpiresAt, now, now)
+	result, err := conn(ctx, t.db).ExecContext(ctx, query, token.ID, token.UserID, utils.HashToken(t.hashKey, token.Token), token.Type, token.FamilyID, token.ExpiresAt, token.UserAgent, token.IPAddress, now, now)
 	if err != nil {
-		log.Printf("Error inserting token: %v", err)
+		logger.FromContext(ctx).Error("Error inserting token", "error", err)
 		return err // ❌ Missing return on insert error
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		log.Printf("Error fetching rows affected: %v", err)
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Rows affected", "rows_affected", rowsAffected)
+	return nil
+}
+
+// MarkUsed implements repository.TokenRepository.
+func (t *tokenRepositoryImpl) MarkUsed(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	query := `UPDATE tokens SET used_at = $2, updated_at = $2
+		WHERE id = $1 AND used_at IS NULL AND deleted_at IS NULL`
+
+	result, err := conn(ctx, t.db).ExecContext(ctx, query, tokenID, time.Now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Error("Error marking token as used", "token_id", tokenID, "error", err)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// RevokeFamily implements repository.TokenRepository.
+func (t *tokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `UPDATE tokens SET deleted_at = $2, updated_at = $2
+		WHERE COALESCE(family_id, id) = $1 AND deleted_at IS NULL`
+
+	result, err := conn(ctx, t.db).ExecContext(ctx, query, familyID, time.Now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Error("Error revoking token family", "family_id", familyID, "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Revoked tokens", "count", rowsAffected, "family_id", familyID)
+	return nil
+}
+
+// RevokeAllForUser implements repository.TokenRepository.
+func (t *tokenRepositoryImpl) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE tokens SET deleted_at = $2, updated_at = $2
+		WHERE user_id = $1 AND deleted_at IS NULL`
+
+	result, err := conn(ctx, t.db).ExecContext(ctx, query, userID, time.Now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Error("Error revoking tokens", "user_id", userID, "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
 		return err
 	}
 
-	log.Printf("Rows affected: %d", rowsAffected)
+	logger.FromContext(ctx).Info("Revoked tokens", "count", rowsAffected, "user_id", userID)
 	return nil
 }
+
+// RevokeByType implements repository.TokenRepository.
+func (t *tokenRepositoryImpl) RevokeByType(ctx context.Context, userID uuid.UUID, tokenType string) error {
+	query := `UPDATE tokens SET deleted_at = $3, updated_at = $3
+		WHERE user_id = $1 AND type = $2 AND deleted_at IS NULL`
+
+	result, err := conn(ctx, t.db).ExecContext(ctx, query, userID, tokenType, time.Now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Error("Error revoking tokens", "user_id", userID, "type", tokenType, "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Revoked tokens", "count", rowsAffected, "user_id", userID, "type", tokenType)
+	return nil
+}
+
+// IsFamilyRevoked implements repository.TokenRepository.
+func (t *tokenRepositoryImpl) IsFamilyRevoked(ctx context.Context, familyID uuid.UUID) (bool, error) {
+	query := `SELECT NOT EXISTS (
+		SELECT 1 FROM tokens WHERE COALESCE(family_id, id) = $1 AND deleted_at IS NULL
+	)`
+
+	var revoked bool
+	if err := conn(ctx, t.db).QueryRowContext(ctx, query, familyID).Scan(&revoked); err != nil {
+		logger.FromContext(ctx).Error("Error checking token family", "family_id", familyID, "error", err)
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// ListSessions implements repository.TokenRepository.
+// A session's active token is the single unused, unrevoked refresh token in its family.
+func (t *tokenRepositoryImpl) ListSessions(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error) {
+	query := `SELECT family, started_at, created_at, expires_at, user_agent, ip_address FROM (
+			SELECT COALESCE(family_id, id) AS family,
+				MIN(created_at) OVER (PARTITION BY COALESCE(family_id, id)) AS started_at,
+				created_at, expires_at, user_agent, ip_address, used_at, deleted_at
+			FROM tokens WHERE user_id = $1 AND type = $2
+		) t
+		WHERE used_at IS NULL AND deleted_at IS NULL AND expires_at > $3
+		ORDER BY created_at DESC`
+
+	rows, err := conn(ctx, t.db).QueryContext(ctx, query, userID, entity.TokenTypeRefresh, time.Now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Error("Error retrieving sessions", "user_id", userID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*entity.Session
+	for rows.Next() {
+		var session entity.Session
+		err := rows.Scan(&session.ID, &session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt, &session.UserAgent, &session.IPAddress)
+		if err != nil {
+			logger.FromContext(ctx).Error("Error scanning session", "error", err)
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("Error iterating sessions", "error", err)
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// PurgeStale implements repository.TokenRepository.
+func (t *tokenRepositoryImpl) PurgeStale(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, t.db).ExecContext(ctx, `DELETE FROM tokens WHERE deleted_at < $1 OR expires_at < $1`, before)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error purging stale tokens", "error", err)
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
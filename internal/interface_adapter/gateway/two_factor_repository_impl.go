@@ -0,0 +1,152 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/utils"
+	"github.com/gofrs/uuid"
+)
+
+// twoFactorRepositoryImpl is the implementation of TwoFactorRepository.
+// Recovery codes are stored as keyed hashes, like tokens.
+type twoFactorRepositoryImpl struct {
+	db      *sql.DB
+	hashKey []byte
+}
+
+// NewTwoFactorRepository creates a new instance of TwoFactorRepository.
+func NewTwoFactorRepository(db *sql.DB, hashKey []byte) repository.TwoFactorRepository {
+	return &twoFactorRepositoryImpl{db: db, hashKey: hashKey}
+}
+
+// FindByUser implements repository.TwoFactorRepository.
+func (t *twoFactorRepositoryImpl) FindByUser(ctx context.Context, userID uuid.UUID) (*entity.TwoFactor, error) {
+	var twoFactor entity.TwoFactor
+
+	query := `SELECT user_id, secret, confirmed_at, last_used_step, created_at, updated_at
+		FROM user_two_factor WHERE user_id = $1`
+
+	err := conn(ctx, t.db).QueryRowContext(ctx, query, userID).Scan(
+		&twoFactor.UserID, &twoFactor.Secret, &twoFactor.ConfirmedAt, &twoFactor.LastUsedStep,
+		&twoFactor.CreatedAt, &twoFactor.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrTwoFactorNotFound
+		}
+		logger.FromContext(ctx).Error("Error retrieving two-factor enrollment", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	return &twoFactor, nil
+}
+
+// Save implements repository.TwoFactorRepository.
+func (t *twoFactorRepositoryImpl) Save(ctx context.Context, twoFactor *entity.TwoFactor) error {
+	query := `INSERT INTO user_two_factor (user_id, secret, confirmed_at, last_used_step, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, confirmed_at = EXCLUDED.confirmed_at,
+			last_used_step = EXCLUDED.last_used_step, updated_at = EXCLUDED.updated_at`
+
+	_, err := conn(ctx, t.db).ExecContext(ctx, query, twoFactor.UserID, twoFactor.Secret, twoFactor.ConfirmedAt, twoFactor.LastUsedStep, time.Now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Error("Error saving two-factor enrollment", "user_id", twoFactor.UserID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// Delete implements repository.TwoFactorRepository.
+func (t *twoFactorRepositoryImpl) Delete(ctx context.Context, userID uuid.UUID) error {
+	return inTx(ctx, t.db, func(tx dbtx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+			logger.FromContext(ctx).Error("Error deleting recovery codes", "user_id", userID, "error", err)
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM user_two_factor WHERE user_id = $1`, userID)
+		if err != nil {
+			logger.FromContext(ctx).Error("Error deleting two-factor enrollment", "user_id", userID, "error", err)
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return repository.ErrTwoFactorNotFound
+		}
+		return nil
+	})
+}
+
+// MarkStepUsed implements repository.TwoFactorRepository.
+func (t *twoFactorRepositoryImpl) MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	query := `UPDATE user_two_factor SET last_used_step = $2, updated_at = $3
+		WHERE user_id = $1 AND last_used_step < $2`
+
+	result, err := conn(ctx, t.db).ExecContext(ctx, query, userID, step, time.Now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Error("Error recording two-factor code use", "user_id", userID, "error", err)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// ReplaceRecoveryCodes implements repository.TwoFactorRepository.
+func (t *twoFactorRepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []string) error {
+	return inTx(ctx, t.db, func(tx dbtx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+			logger.FromContext(ctx).Error("Error deleting recovery codes", "user_id", userID, "error", err)
+			return err
+		}
+
+		now := time.Now().UTC()
+		for _, code := range codes {
+			_, err := tx.ExecContext(ctx, `INSERT INTO recovery_codes (user_id, code_hash, created_at) VALUES ($1, $2, $3)`,
+				userID, utils.HashToken(t.hashKey, code), now)
+			if err != nil {
+				logger.FromContext(ctx).Error("Error inserting recovery code", "user_id", userID, "error", err)
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UseRecoveryCode implements repository.TwoFactorRepository.
+func (t *twoFactorRepositoryImpl) UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string) (bool, error) {
+	query := `UPDATE recovery_codes SET used_at = $3
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	result, err := conn(ctx, t.db).ExecContext(ctx, query, userID, utils.HashToken(t.hashKey, code), time.Now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Error("Error using recovery code", "user_id", userID, "error", err)
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
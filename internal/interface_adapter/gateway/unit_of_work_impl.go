@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"github.com/lib/pq"
+)
+
+// maxTxAttempts bounds how often a transaction is retried after a serialization failure
+const maxTxAttempts = 5
+
+// txRetryBackoff is the delay before the first retry; it doubles on each attempt
+const txRetryBackoff = 10 * time.Millisecond
+
+// Postgres error codes the gateways react to
+const (
+	foreignKeyViolation  = "23503"
+	uniqueViolation      = "23505"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// txKey is the context key under which a unit of work stores its transaction
+type txKey struct{}
+
+// dbtx is the part of *sql.DB and *sql.Tx that repositories query through.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction bound to ctx by a unit of work, or db when
+// the call is not part of one.
+func conn(ctx context.Context, db *sql.DB) dbtx {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// inTx runs fn in the transaction bound to ctx, or in a transaction of its
+// own when there is none, so multi-statement writes stay atomic either way.
+func inTx(ctx context.Context, db *sql.DB, fn func(tx dbtx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error starting transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// unitOfWorkImpl is the implementation of UnitOfWork.
+type unitOfWorkImpl struct {
+	db *sql.DB
+}
+
+// NewUnitOfWork creates a UnitOfWork whose transactions run at the
+// serializable isolation level.
+func NewUnitOfWork(db *sql.DB) repository.UnitOfWork {
+	return &unitOfWorkImpl{db: db}
+}
+
+// Do implements repository.UnitOfWork.
+func (u *unitOfWorkImpl) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	backoff := txRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := u.run(ctx, fn)
+		if err == nil || !isSerializationFailure(err) || attempt == maxTxAttempts {
+			return err
+		}
+
+		logger.FromContext(ctx).Warn("Retrying transaction after serialization failure", "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// run executes fn once in a new transaction.
+func (u *unitOfWorkImpl) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := u.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		logger.FromContext(ctx).Error("Error starting transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.FromContext(ctx).Error("Error committing transaction", "error", err)
+		return err
+	}
+	return nil
+}
+
+// isSerializationFailure reports whether err means the transaction lost a
+// conflict with a concurrent one and can safely be run again.
+func isSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == serializationFailure || pqErr.Code == deadlockDetected
+}
+
+// isPQError reports whether err is a Postgres error with the given code.
+func isPQError(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
@@ -1,14 +1,13 @@
 package gateway
 
 import (
+	"context"
 	"database/sql"
-	"errors"
-	"fmt"
-	"log"
 	"time"
 
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
 	"github.com/gofrs/uuid"
 )
 
@@ -23,130 +22,135 @@ func NewUserRepository(db *sql.DB) repository.UserRepository {
 }
 
 // Create implements repository.UserRepository.
-func (u *userRepositoryImpl) Create(user *entity.User) error {
+func (u *userRepositoryImpl) Create(ctx context.Context, user *entity.User) error {
 	// Generate a new UUID for the user
 	newUUID, err := uuid.NewV4()
 	if err != nil {
-		log.Printf("Error generating UUID: %v", err)
+		logger.FromContext(ctx).Error("Error generating UUID", "error", err)
 		return err
 	}
 	user.ID = newUUID
 
 	// Log user creation
-	log.Printf("Inserting User: ID=%s, Username=%s, Email=%s, Password=%s FirstName=%s, LastName=%s, IsActive=%v\n",
-		user.ID, user.Username, user.Email, user.Password, user.FirstName, user.LastName, user.IsActive)
+	logger.FromContext(ctx).Info("Inserting user", "user_id", user.ID, "username", user.Username, "email", user.Email, "is_active", user.IsActive)
 
 	// Corrected SQL query
-	query := `INSERT INTO users (id, username, password, email, first_name, last_name, is_active, created_at, updated_at)
-	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	query := `INSERT INTO users (id, username, password, email, first_name, last_name, is_active, email_verified_at, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	// Execute the query
-	result, err := u.db.Exec(query, user.ID, user.Username, user.Password, user.Email, user.FirstName, user.LastName, user.IsActive, time.Now(), time.Now())
+	result, err := conn(ctx, u.db).ExecContext(ctx, query, user.ID, user.Username, user.Password, user.Email, user.FirstName, user.LastName, user.IsActive, user.EmailVerifiedAt, time.Now(), time.Now())
 	if err != nil {
-		log.Printf("Error inserting user: %v", err)
+		if isPQError(err, uniqueViolation) {
+			return repository.ErrUserTaken
+		}
+		logger.FromContext(ctx).Error("Error inserting user", "error", err)
 		return err
 	}
 
 	// Get the number of rows affected
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		log.Printf("Error fetching rows affected: %v", err)
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
 	} else {
-		log.Printf("Rows affected: %d", rowsAffected)
+		logger.FromContext(ctx).Info("Rows affected", "rows_affected", rowsAffected)
 	}
 
 	// Fetch the inserted user (optional)
 	var insertedUser entity.User
-	err = u.db.QueryRow(`SELECT id, username, email, password, first_name, last_name, is_active, created_at, updated_at 
-	                    FROM users WHERE email=$1`, user.Email).Scan(
+	err = conn(ctx, u.db).QueryRowContext(ctx, `SELECT id, username, email, password, first_name, last_name, is_active, email_verified_at, created_at, updated_at 
+	                    FROM users WHERE email=$1 AND deleted_at IS NULL`, user.Email).Scan(
 		&insertedUser.ID, &insertedUser.Username, &insertedUser.Email, &insertedUser.Password,
-		&insertedUser.FirstName, &insertedUser.LastName, &insertedUser.IsActive, &insertedUser.CreatedAt, &insertedUser.UpdatedAt)
+		&insertedUser.FirstName, &insertedUser.LastName, &insertedUser.IsActive, &insertedUser.EmailVerifiedAt, &insertedUser.CreatedAt, &insertedUser.UpdatedAt)
 
 	if err != nil {
-		log.Printf("Error retrieving inserted user: %v", err)
+		logger.FromContext(ctx).Error("Error retrieving inserted user", "error", err)
 		return err
 	}
 
-	log.Printf("Inserted User: %+v", insertedUser)
+	logger.FromContext(ctx).Info("Inserted user", "user_id", insertedUser.ID)
 
 	return nil
 }
 
 // Update implements repository.UserRepository.
-func (u *userRepositoryImpl) Update(user *entity.User) error {
+func (u *userRepositoryImpl) Update(ctx context.Context, user *entity.User) error {
 	query := `UPDATE users
-	          SET username = $1, email = $2, password = $3, first_name = $4, last_name = $5, is_active =$6, updated_at = $7
-			  WHERE id = $8`
+	          SET username = $1, email = $2, password = $3, first_name = $4, last_name = $5, is_active =$6, email_verified_at = $7, updated_at = $8
+			  WHERE id = $9 AND deleted_at IS NULL`
 
 	// Execute the update query with the user data
-	result, err := u.db.Exec(query, user.Username, user.Email, user.Password, user.FirstName, user.LastName, user.IsActive, time.Now(), user.ID)
+	result, err := conn(ctx, u.db).ExecContext(ctx, query, user.Username, user.Email, user.Password, user.FirstName, user.LastName, user.IsActive, user.EmailVerifiedAt, time.Now(), user.ID)
 	if err != nil {
-		log.Printf("Error updating user with ID: %v, error: %v", user.ID, err)
+		if isPQError(err, uniqueViolation) {
+			return repository.ErrUserTaken
+		}
+		logger.FromContext(ctx).Error("Error updating user", "user_id", user.ID, "error", err)
 		return err
 	}
 
 	// Check how many rows were affected by the update
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		log.Printf("Error fetching rows affected: %v", err)
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
 		return err
 	}
 
 	// If no rows were affected, it means the user was not found
 	if rowsAffected == 0 {
-		log.Printf("No user found with ID: %v", user.ID)
-		return fmt.Errorf("user not found")
+		logger.FromContext(ctx).Warn("No user found", "user_id", user.ID)
+		return repository.ErrUserNotFound
 	}
 
-	log.Printf("Updated user with ID: %v", user.ID)
+	logger.FromContext(ctx).Info("Updated user", "user_id", user.ID)
 	return nil
 }
 
 // Delete implements repository.UserRepository.
-func (u *userRepositoryImpl) Delete(userID uuid.UUID) error {
-	// Define the SQL delete query
-	query := `DELETE FROM users WHERE id = $1`
+func (u *userRepositoryImpl) Delete(ctx context.Context, userID uuid.UUID) error {
+	// Soft delete: the row is kept until the purge job removes it
+	query := `UPDATE users SET deleted_at = $2, updated_at = $2 WHERE id = $1 AND deleted_at IS NULL`
 
 	// Execute the delete query with the user ID
-	result, err := u.db.Exec(query, userID)
+	result, err := conn(ctx, u.db).ExecContext(ctx, query, userID, time.Now())
 	if err != nil {
-		log.Printf("Error deleting user with ID: %v, error: %v", userID, err)
+		logger.FromContext(ctx).Error("Error deleting user", "user_id", userID, "error", err)
 		return err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		log.Printf("Error fetching rows affected: %v", err)
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
 		return err
 	}
 
 	// If no rows were affected, it means the user was not found
 	if rowsAffected == 0 {
-		log.Printf("No user found with ID: %v", userID)
-		return fmt.Errorf("user not found")
+		logger.FromContext(ctx).Warn("No user found", "user_id", userID)
+		return repository.ErrUserNotFound
 	}
 
-	log.Printf("Deleted user with ID: %v", userID)
+	logger.FromContext(ctx).Info("Deleted user", "user_id", userID)
 	return nil
 }
 
 // FindByID implements repository.UserRepository.
-func (u *userRepositoryImpl) FindByID(userID uuid.UUID) (*entity.User, error) {
+func (u *userRepositoryImpl) FindByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
 	// Define the user entity to store the result
 	var user entity.User
 
 	// Fetch the user from the database using the provided ID
-	err := u.db.QueryRow(`SELECT id, username, email, password, first_name, last_name, is_active, created_at, updated_at 
-	              FROM users WHERE id = $1`, userID).Scan(
-		&user.ID, &user.Username, &user.Email, &user.Password, &user.FirstName, &user.LastName, &user.IsActive, &user.CreatedAt, &user.UpdatedAt)
+	err := conn(ctx, u.db).QueryRowContext(ctx, `SELECT id, username, email, password, first_name, last_name, is_active, email_verified_at, created_at, updated_at 
+	              FROM users WHERE id = $1 AND deleted_at IS NULL`, userID).Scan(
+		&user.ID, &user.Username, &user.Email, &user.Password, &user.FirstName, &user.LastName, &user.IsActive, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
 
 	// Check for errors in retrieving the user
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Printf("No user found with ID: %v", userID)
-			return nil, fmt.Errorf("user with ID %v not found", userID)
+			logger.FromContext(ctx).Warn("No user found", "user_id", userID)
+			return nil, repository.ErrUserNotFound
 		}
-		log.Printf("Error retrieving user by ID: %v", err)
+		logger.FromContext(ctx).Error("Error retrieving user", "error", err)
 		return nil, err
 	}
 
@@ -155,17 +159,17 @@ func (u *userRepositoryImpl) FindByID(userID uuid.UUID) (*entity.User, error) {
 }
 
 // FindByEmail implements repository.UserRepository.
-func (u *userRepositoryImpl) FindByEmail(email string) (*entity.User, error) {
+func (u *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	user := &entity.User{}
-	query := "SELECT id, username, email, password, first_name, last_name, is_active, created_at, updated_at FROM users WHERE email = $1"
-	row := u.db.QueryRow(query, email)
+	query := "SELECT id, username, email, password, first_name, last_name, is_active, email_verified_at, created_at, updated_at FROM users WHERE email = $1 AND deleted_at IS NULL"
+	row := conn(ctx, u.db).QueryRowContext(ctx, query, email)
 
-	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.FirstName, &user.LastName, &user.IsActive, &user.CreatedAt, &user.UpdatedAt)
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.FirstName, &user.LastName, &user.IsActive, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
-		if err != sql.ErrNoRows {
-			return nil, errors.New("user not found")
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrUserNotFound
 		}
-
+		logger.FromContext(ctx).Error("Error retrieving user", "error", err)
 		return nil, err
 	}
 
@@ -173,9 +177,9 @@ func (u *userRepositoryImpl) FindByEmail(email string) (*entity.User, error) {
 }
 
 // ListAll implements repository.UserRepository.
-func (u *userRepositoryImpl) ListAll() ([]*entity.User, error) {
+func (u *userRepositoryImpl) ListAll(ctx context.Context) ([]*entity.User, error) {
 	// Fetch all users from the database
-	rows, err := u.db.Query("SELECT id, username, email, password, first_name, last_name, is_active, created_at, updated_at FROM users")
+	rows, err := conn(ctx, u.db).QueryContext(ctx, "SELECT id, username, email, password, first_name, last_name, is_active, email_verified_at, created_at, updated_at FROM users WHERE deleted_at IS NULL")
 	if err != nil {
 		return nil, err
 	}
@@ -186,7 +190,7 @@ func (u *userRepositoryImpl) ListAll() ([]*entity.User, error) {
 	var users []*entity.User
 	for rows.Next() {
 		var user entity.User
-		err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.FirstName, &user.LastName, &user.IsActive, &user.CreatedAt, &user.UpdatedAt)
+		err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.FirstName, &user.LastName, &user.IsActive, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -195,3 +199,76 @@ func (u *userRepositoryImpl) ListAll() ([]*entity.User, error) {
 	// Check for any errors during the scan
 	return users, nil
 }
+
+// ListDeleted implements repository.UserRepository.
+func (u *userRepositoryImpl) ListDeleted(ctx context.Context) ([]*entity.User, error) {
+	rows, err := conn(ctx, u.db).QueryContext(ctx, `SELECT id, username, email, password, first_name, last_name, is_active, email_verified_at, created_at, updated_at, deleted_at
+		FROM users WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error retrieving deleted users", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		var user entity.User
+		err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.FirstName, &user.LastName, &user.IsActive, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
+		if err != nil {
+			logger.FromContext(ctx).Error("Error scanning deleted user", "error", err)
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.FromContext(ctx).Error("Error iterating deleted users", "error", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Restore implements repository.UserRepository.
+func (u *userRepositoryImpl) Restore(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE users SET deleted_at = NULL, updated_at = $2 WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := conn(ctx, u.db).ExecContext(ctx, query, userID, time.Now())
+	if err != nil {
+		// Someone else may have taken the username or email since the account was deleted
+		if isPQError(err, uniqueViolation) {
+			return repository.ErrUserTaken
+		}
+		logger.FromContext(ctx).Error("Error restoring user", "user_id", userID, "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.FromContext(ctx).Error("Error fetching rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrUserNotDeleted
+	}
+
+	logger.FromContext(ctx).Info("Restored user", "user_id", userID)
+	return nil
+}
+
+// PurgeDeleted implements repository.UserRepository.
+func (u *userRepositoryImpl) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	// Removing a user cascades to their events, so users who still organize
+	// live events are kept until those events are deleted too
+	query := `DELETE FROM users WHERE deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM events WHERE organizer_id = users.id AND deleted_at IS NULL)`
+
+	result, err := conn(ctx, u.db).ExecContext(ctx, query, before)
+	if err != nil {
+		logger.FromContext(ctx).Error("Error purging deleted users", "error", err)
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/interface_adapter/controller"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/auth"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterAuthRoutes sets up the routes for session token handling.
+func RegisterAuthRoutes(router *gin.Engine, userController *controller.UserController, tokenManager *auth.TokenManager, revocations *auth.RevocationList) {
+	authMiddleware := middlewares.AuthMiddleware(tokenManager, revocations)
+
+	authGroup := router.Group("/auth")
+	{
+		// Public routes
+		authGroup.POST("/refresh", userController.RefreshToken) // Route for exchanging a refresh token
+
+		// Protected routes (require valid authentication)
+		authGroup.Use(authMiddleware)
+		{
+			authGroup.POST("/logout", userController.Logout)                // Route for signing out the current session
+			authGroup.POST("/logout-all", userController.LogoutAll)         // Route for signing out everywhere
+			authGroup.GET("/sessions", userController.ListSessions)         // Route for listing active sessions
+			authGroup.DELETE("/sessions/:id", userController.RevokeSession) // Route for signing out one session
+
+			authGroup.POST("/2fa/setup", userController.SetupTwoFactor)                   // Route for starting two-factor enrollment
+			authGroup.POST("/2fa/confirm", userController.ConfirmTwoFactor)               // Route for enabling two-factor authentication
+			authGroup.POST("/2fa/disable", userController.DisableTwoFactor)               // Route for turning off two-factor authentication
+			authGroup.POST("/2fa/recovery-codes", userController.RegenerateRecoveryCodes) // Route for replacing recovery codes
+		}
+	}
+}
@@ -1,25 +1,56 @@
 package routes
 
 import (
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/interface_adapter/controller"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/auth"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
-func RegistereventsRoutes(routes *gin.Engine, eventController *controller.EventController, tokenRepo repository.TokenRepository) {
-	authMiddleware := middlewares.AuthMiddleware(tokenRepo)
+func RegistereventsRoutes(routes *gin.Engine, eventController *controller.EventController, tokenManager *auth.TokenManager, revocations *auth.RevocationList, permissionRepo repository.PermissionRepository) {
+	authMiddleware := middlewares.AuthMiddleware(tokenManager, revocations)
+	permissionMiddleware := middlewares.PermissionMiddleware(permissionRepo)
+
+	read := middlewares.RequirePermission(entity.PermissionEventsRead)
+	manage := middlewares.RequirePermission(entity.PermissionEventsManage)
+	register := middlewares.RequirePermission(entity.PermissionEventsRegister)
 
 	eventGroup := routes.Group("/events")
 	{
 		// Protected routes (require valid authentication)
-		eventGroup.Use(authMiddleware)
+		eventGroup.Use(authMiddleware, permissionMiddleware)
 		{
-			eventGroup.POST("", eventController.CreateEvent)
-			eventGroup.PUT("/:id", eventController.UpdateEvent)
-			eventGroup.DELETE("/:id", eventController.DeleteEvent)
-			eventGroup.GET("/:id", eventController.GetEventByID)
-			eventGroup.GET("", eventController.ListtAllEvents)
+			eventGroup.POST("", middlewares.RequirePermission(entity.PermissionEventsCreate), eventController.CreateEvent)
+			eventGroup.PUT("/:id", middlewares.RequirePermission(entity.PermissionEventsUpdate), eventController.UpdateEvent)
+			eventGroup.DELETE("/:id", middlewares.RequirePermission(entity.PermissionEventsDelete), eventController.DeleteEvent)
+			eventGroup.GET("/:id", read, eventController.GetEventByID)
+			eventGroup.GET("", read, eventController.ListtAllEvents)
+
+			// Soft-deleted events, for administrators
+			eventGroup.GET("/deleted", middlewares.RequirePermission(entity.PermissionEventsAdmin), eventController.ListDeletedEvents)
+			eventGroup.POST("/:id/restore", middlewares.RequirePermission(entity.PermissionEventsAdmin), eventController.RestoreEvent)
+
+			// Co-organizers
+			eventGroup.GET("/:id/organizers", read, eventController.ListCoOrganizers)
+			eventGroup.POST("/:id/organizers", middlewares.RequirePermission(entity.PermissionEventsUpdate), eventController.AddCoOrganizer)
+			eventGroup.DELETE("/:id/organizers/:userID", middlewares.RequirePermission(entity.PermissionEventsUpdate), eventController.RemoveCoOrganizer)
+
+			// Lifecycle transitions
+			eventGroup.POST("/:id/publish", manage, eventController.PublishEvent)
+			eventGroup.POST("/:id/unpublish", manage, eventController.UnpublishEvent)
+			eventGroup.POST("/:id/open-registration", manage, eventController.OpenRegistration)
+			eventGroup.POST("/:id/close-registration", manage, eventController.CloseRegistration)
+			eventGroup.POST("/:id/start", manage, eventController.StartEvent)
+			eventGroup.POST("/:id/complete", manage, eventController.CompleteEvent)
+			eventGroup.POST("/:id/cancel", manage, eventController.CancelEvent)
+			eventGroup.POST("/:id/archive", manage, eventController.ArchiveEvent)
+
+			eventGroup.POST("/:id/registrations", register, eventController.RegisterForEvent)
+			eventGroup.DELETE("/:id/registrations", register, eventController.CancelRegistration)
+			eventGroup.GET("/:id/registrations", manage, eventController.ListRegistrations)
+			eventGroup.GET("/:id/registrations/me", register, eventController.GetRegistration)
 		}
 	}
 }
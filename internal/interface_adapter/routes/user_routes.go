@@ -1,32 +1,48 @@
 package routes
 
 import (
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/interface_adapter/controller"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/auth"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
 // RegisterUserRoutes sets up the routes for user-related operations.
-func RegisterUserRoutes(router *gin.Engine, userController *controller.UserController, tokenRepo repository.TokenRepository) {
+func RegisterUserRoutes(router *gin.Engine, userController *controller.UserController, tokenManager *auth.TokenManager, revocations *auth.RevocationList, permissionRepo repository.PermissionRepository) {
 
 	// Apply middleware to protect certain routes
-	authMiddleware := middlewares.AuthMiddleware(tokenRepo)
+	authMiddleware := middlewares.AuthMiddleware(tokenManager, revocations)
+	permissionMiddleware := middlewares.PermissionMiddleware(permissionRepo)
 
 	// User-related routes
 	userGroup := router.Group("/user")
 	{
 		// Public routes
-		userGroup.POST("", userController.RegisterUser)                  // Route for user registration
-		userGroup.POST("/authenticate", userController.AuthenticateUser) // Route for user authentication
+		userGroup.POST("", userController.RegisterUser)                     // Route for user registration
+		userGroup.POST("/authenticate", userController.AuthenticateUser)    // Route for user authentication
+		userGroup.POST("/password/forgot", userController.ForgotPassword)   // Route for requesting a password reset code
+		userGroup.POST("/password/reset", userController.ResetPassword)     // Route for setting a new password with a reset code
+		userGroup.POST("/verify", userController.VerifyEmail)               // Route for confirming an email address
+		userGroup.POST("/verify/resend", userController.ResendVerification) // Route for requesting a new verification code
 
 		// Protected routes (require valid authentication)
-		userGroup.Use(authMiddleware) // Apply middleware here without additional braces
+		userGroup.Use(authMiddleware, permissionMiddleware) // Apply middleware here without additional braces
 		{
-			userGroup.PUT("/:id", userController.UpdateUser)    // Route for updating user information
-			userGroup.DELETE("/:id", userController.DeleteUser) // Route for deactivating a user
-			userGroup.GET("/:id", userController.GetUserByID)   // Route for getting a user by ID
-			userGroup.GET("", userController.ListUsers)         // Route for listing all users
+			userGroup.PUT("/:id", middlewares.RequireSelfOrPermission(entity.PermissionUsersUpdate), userController.UpdateUser)    // Route for updating user information
+			userGroup.DELETE("/:id", middlewares.RequireSelfOrPermission(entity.PermissionUsersDelete), userController.DeleteUser) // Route for deactivating a user
+			userGroup.GET("/:id", middlewares.RequireSelfOrPermission(entity.PermissionUsersRead), userController.GetUserByID)     // Route for getting a user by ID
+			userGroup.GET("", middlewares.RequirePermission(entity.PermissionUsersList), userController.ListUsers)                 // Route for listing all users
+
+			userGroup.GET("/:id/roles", middlewares.RequireSelfOrPermission(entity.PermissionUsersRead), userController.ListRoles)       // Route for listing a user's roles
+			userGroup.POST("/:id/roles", middlewares.RequirePermission(entity.PermissionRolesAssign), userController.AssignRole)         // Route for assigning a role
+			userGroup.DELETE("/:id/roles/:role", middlewares.RequirePermission(entity.PermissionRolesAssign), userController.RemoveRole) // Route for removing a role
+
+			userGroup.POST("/:id/unlock", middlewares.RequirePermission(entity.PermissionUsersUnlock), userController.UnlockUser) // Route for lifting a sign-in lockout
+
+			userGroup.GET("/deleted", middlewares.RequirePermission(entity.PermissionUsersList), userController.ListDeletedUsers)   // Route for listing soft-deleted users
+			userGroup.POST("/:id/restore", middlewares.RequirePermission(entity.PermissionUsersDelete), userController.RestoreUser) // Route for undoing a user delete
 		}
 	}
 
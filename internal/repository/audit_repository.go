@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, event *entity.AuditEvent) error
+}
@@ -1,24 +1,122 @@
 package repository
 
 import (
+	"context"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"github.com/gofrs/uuid"
 )
 
+var (
+	// ErrEventNotFound is returned when no live event has the given ID
+	ErrEventNotFound = apperror.NewNotFound("event not found")
+
+	// ErrStaleEventStatus is returned when an event's status changed before a transition could be applied
+	ErrStaleEventStatus = apperror.NewConflict("event status was changed by another request")
+
+	// ErrEventNotDeleted is returned when restoring an event that does not exist or was not deleted
+	ErrEventNotDeleted = apperror.NewNotFound("no deleted event with this ID")
+)
+
+// Orders in which events can be listed. Ties are broken by event ID.
+const (
+	EventSortStartTime = "start_time"
+	EventSortCreatedAt = "created_at"
+	EventSortTitle     = "title"
+)
+
+// EventFilter selects and orders a page of live events. Zero-valued filter
+// fields match every event.
+type EventFilter struct {
+	Status      string
+	OrganizerID uuid.UUID
+	// Location matches events whose location contains it, ignoring case
+	Location string
+	// From and To match events that overlap the period: ones that have not
+	// ended by From and have started by To
+	From     *time.Time
+	To       *time.Time
+	IsPublic *bool
+	// Search matches events whose title contains every word of it, each as
+	// the start of a word
+	Search string
+
+	// ViewerID limits the listing to the events its user may see: public
+	// events that are not drafts, and the events they organize or
+	// co-organize. ViewAll lifts the limit for administrators.
+	ViewerID uuid.UUID
+	ViewAll  bool
+
+	Sort       string
+	Descending bool
+	// After continues a listing from the last event of the previous page
+	After *EventCursor
+	Limit int
+}
+
+// EventCursor is the position of an event in a listing: the value it is
+// sorted by (a time.Time, or a string for EventSortTitle) and its ID
+type EventCursor struct {
+	Value any
+	ID    uuid.UUID
+}
+
+// EventPage is one page of an event listing
+type EventPage struct {
+	Events []*entity.Event
+	// Next is the position of the last event, or nil on the last page
+	Next *EventCursor
+	// Total counts the events matching the filter on every page
+	Total int
+}
+
 type EventRepository interface {
 
 	// CreateEvent creates a new event
-	Create(event *entity.Event) error
+	Create(ctx context.Context, event *entity.Event) error
 
 	// Updatevent updates an existing event
-	Update(event *entity.Event) error
+	Update(ctx context.Context, event *entity.Event) error
+
+	// UpdateStatus moves an event from one status to another, failing if the current status is not from
+	UpdateStatus(ctx context.Context, eventID uuid.UUID, from, to string) error
 
 	// Deleteevent deletes a event by its ID
-	Delete(eventID uuid.UUID) error
+	Delete(ctx context.Context, eventID uuid.UUID) error
 
 	// Getevent returns a event by its ID
-	GetByID(eventID uuid.UUID) (*entity.Event, error)
+	GetByID(ctx context.Context, eventID uuid.UUID) (*entity.Event, error)
+
+	// GetVisibleByID returns an event by its ID if viewerID may see it, by
+	// the rule of EventFilter.ViewerID, and ErrEventNotFound otherwise
+	GetVisibleByID(ctx context.Context, eventID, viewerID uuid.UUID) (*entity.Event, error)
+
+	// LockByID returns an event by its ID and locks it until the surrounding
+	// transaction ends, so concurrent changes to its registrations serialize
+	LockByID(ctx context.Context, eventID uuid.UUID) (*entity.Event, error)
+
+	// List returns one page of live events matching filter, in the order it
+	// asks for, with the number of events that match across all pages
+	List(ctx context.Context, filter EventFilter) (*EventPage, error)
+
+	// ListCoOrganizers returns the IDs of users who help organize an event
+	ListCoOrganizers(ctx context.Context, eventID uuid.UUID) ([]uuid.UUID, error)
+
+	// AddCoOrganizer lets a user help organize an event
+	AddCoOrganizer(ctx context.Context, eventID, userID uuid.UUID) error
+
+	// RemoveCoOrganizer revokes a user's co-organizer access to an event
+	RemoveCoOrganizer(ctx context.Context, eventID, userID uuid.UUID) error
+
+	// ListDeleted returns soft-deleted events, most recently deleted first
+	ListDeleted(ctx context.Context) ([]*entity.Event, error)
+
+	// Restore undoes a soft delete
+	Restore(ctx context.Context, eventID uuid.UUID) error
 
-	// Getevents returns all event
-	GetAll() ([]*entity.Event, error)
+	// PurgeDeleted permanently removes events soft-deleted before the cutoff
+	// and returns how many were removed
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+)
+
+type LoginAttemptRepository interface {
+	// Get returns the attempts recorded for a key, or nil when there are none
+	Get(ctx context.Context, key string) (*entity.LoginAttempt, error)
+
+	// RecordFailure counts a failed attempt and returns the new failure count.
+	// The count starts over when the previous failure is older than resetAfter.
+	RecordFailure(ctx context.Context, key string, resetAfter time.Duration) (int, error)
+
+	// Lock locks a key out until the given time
+	Lock(ctx context.Context, key string, until time.Time) error
+
+	// Reset forgets every attempt recorded for a key
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"github.com/gofrs/uuid"
+)
+
+var (
+	// ErrAlreadyRegistered is returned when the user already holds a registration for the event
+	ErrAlreadyRegistered = apperror.NewConflict("user is already registered for this event")
+
+	// ErrRegistrationNotFound is returned when the user has no registration for the event
+	ErrRegistrationNotFound = apperror.NewNotFound("registration not found")
+)
+
+type RegistrationRepository interface {
+
+	// Create registers a user for an event with the status already decided by the caller
+	Create(ctx context.Context, registration *entity.Registration) error
+
+	// Delete removes a user's registration for an event and returns it
+	Delete(ctx context.Context, eventID, userID uuid.UUID) (*entity.Registration, error)
+
+	// CountByStatus returns how many of an event's registrations have the status
+	CountByStatus(ctx context.Context, eventID uuid.UUID, status string) (int, error)
+
+	// PromoteNext confirms the event's longest-waiting waitlisted registration
+	// and returns it, or nil when the waitlist is empty
+	PromoteNext(ctx context.Context, eventID uuid.UUID) (*entity.Registration, error)
+
+	// FindByEventAndUser returns a user's registration for an event
+	FindByEventAndUser(ctx context.Context, eventID, userID uuid.UUID) (*entity.Registration, error)
+
+	// ListByEvent returns all registrations for an event
+	ListByEvent(ctx context.Context, eventID uuid.UUID) ([]*entity.Registration, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"github.com/gofrs/uuid"
+)
+
+type RoleRepository interface {
+
+	// ListByUser returns the roles assigned to a user
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Role, error)
+
+	// AssignToUser gives a user the named role
+	AssignToUser(ctx context.Context, userID uuid.UUID, roleName string) error
+
+	// RemoveFromUser takes the named role away from a user
+	RemoveFromUser(ctx context.Context, userID uuid.UUID, roleName string) error
+}
+
+type PermissionRepository interface {
+
+	// ListByUser returns the names of a user's effective permissions,
+	// combining direct grants with those inherited from roles
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]string, error)
+}
@@ -1,8 +1,41 @@
 package repository
 
-import "example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+import (
+	"context"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"github.com/gofrs/uuid"
+)
 
 type TokenRepository interface {
-	FindByToken(token string) (*entity.Token, error)
-	Create(token *entity.Token) error
+	FindByToken(ctx context.Context, token string) (*entity.Token, error)
+	Create(ctx context.Context, token *entity.Token) error
+
+	// MarkUsed flags a token as exchanged. It reports false when the token was
+	// already used or revoked, which signals refresh token reuse.
+	MarkUsed(ctx context.Context, tokenID uuid.UUID) (bool, error)
+
+	// RevokeFamily revokes every token descended from the same login
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+
+	// RevokeAllForUser revokes every token issued to a user
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+
+	// RevokeByType revokes every unrevoked token of a type issued to a user
+	RevokeByType(ctx context.Context, userID uuid.UUID, tokenType string) error
+
+	// IsFamilyRevoked reports whether no usable token is left in a family
+	IsFamilyRevoked(ctx context.Context, familyID uuid.UUID) (bool, error)
+
+	// FindLatestByUser returns the most recently issued token of a type for a
+	// user, or nil when there is none
+	FindLatestByUser(ctx context.Context, userID uuid.UUID, tokenType string) (*entity.Token, error)
+
+	// ListSessions returns a user's active sessions, most recently used first
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error)
+
+	// PurgeStale permanently removes tokens that were revoked or expired
+	// before the cutoff and returns how many were removed
+	PurgeStale(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"github.com/gofrs/uuid"
+)
+
+// ErrTwoFactorNotFound is returned when a user has not enrolled in two-factor authentication
+var ErrTwoFactorNotFound = apperror.NewNotFound("two-factor authentication is not set up")
+
+type TwoFactorRepository interface {
+	FindByUser(ctx context.Context, userID uuid.UUID) (*entity.TwoFactor, error)
+
+	// Save creates or replaces a user's enrollment
+	Save(ctx context.Context, twoFactor *entity.TwoFactor) error
+
+	// Delete removes a user's enrollment together with their recovery codes
+	Delete(ctx context.Context, userID uuid.UUID) error
+
+	// MarkStepUsed records the time step of an accepted code. It reports false
+	// when that step or a later one was already used, which signals a replay.
+	MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes discards a user's recovery codes and stores new ones
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []string) error
+
+	// UseRecoveryCode consumes a recovery code. It reports false when the code
+	// is unknown or already used.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string) (bool, error)
+}
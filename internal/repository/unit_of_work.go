@@ -0,0 +1,14 @@
+package repository
+
+import "context"
+
+// UnitOfWork groups repository calls into one database transaction.
+type UnitOfWork interface {
+	// Do calls fn with a context bound to a transaction. Repository calls
+	// made with that context join the transaction, which commits when fn
+	// returns nil and rolls back otherwise. A transaction that fails to
+	// serialize with a concurrent one is retried, so fn may run more than
+	// once and should not have side effects outside the database. Calling Do
+	// inside fn joins the outer transaction.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
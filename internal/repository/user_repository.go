@@ -1,15 +1,40 @@
 package repository
 
 import (
+	"context"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"github.com/gofrs/uuid"
 )
 
+var (
+	// ErrUserNotFound is returned when no live user has the given ID or email
+	ErrUserNotFound = apperror.NewNotFound("user not found")
+
+	// ErrUserTaken is returned when a username or email already belongs to another account
+	ErrUserTaken = apperror.NewConflict("username or email is already in use")
+
+	// ErrUserNotDeleted is returned when restoring a user that does not exist or was not deleted
+	ErrUserNotDeleted = apperror.NewNotFound("no deleted user with this ID")
+)
+
 type UserRepository interface {
-	Create(user *entity.User) error
-	Update(user *entity.User) error
-	Delete(userID uuid.UUID) error
-	FindByID(userID uuid.UUID) (*entity.User, error)
-	FindByEmail(email string) (*entity.User, error)
-	ListAll() ([]*entity.User, error)
+	Create(ctx context.Context, user *entity.User) error
+	Update(ctx context.Context, user *entity.User) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+	FindByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	ListAll(ctx context.Context) ([]*entity.User, error)
+
+	// ListDeleted returns soft-deleted users, most recently deleted first
+	ListDeleted(ctx context.Context) ([]*entity.User, error)
+
+	// Restore undoes a soft delete
+	Restore(ctx context.Context, userID uuid.UUID) error
+
+	// PurgeDeleted permanently removes users soft-deleted before the cutoff
+	// who no longer organize any live event, and returns how many were removed
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"github.com/gofrs/uuid"
+)
+
+// ErrInvalidCursor is returned when a listing cursor is malformed or was
+// issued for a different sort order
+var ErrInvalidCursor = apperror.NewBadRequest("invalid pagination cursor")
+
+// eventCursor is the JSON inside the opaque cursor handed to clients. It
+// records the sort order so a cursor cannot be replayed against another one.
+type eventCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// parseEventSort splits a sort such as "-start_time" into its order and
+// whether it is descending
+func parseEventSort(sort string) (string, bool) {
+	order, descending := strings.CutPrefix(sort, "-")
+	return order, descending
+}
+
+// encodeEventCursor turns the position of the last event of a page listed in
+// sort into the cursor of the next page
+func encodeEventCursor(sort string, position *repository.EventCursor) string {
+	cursor := eventCursor{Sort: sort, ID: position.ID}
+	switch value := position.Value.(type) {
+	case time.Time:
+		cursor.Value = value.UTC().Format(time.RFC3339Nano)
+	case string:
+		cursor.Value = value
+	}
+
+	// Marshalling plain strings and a UUID cannot fail
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeEventCursor reads a cursor issued by encodeEventCursor for the
+// same sort back into a position
+func decodeEventCursor(sort, encoded string) (*repository.EventCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var cursor eventCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if cursor.Sort != sort || cursor.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+
+	position := &repository.EventCursor{Value: cursor.Value, ID: cursor.ID}
+	if order, _ := parseEventSort(sort); order != repository.EventSortTitle {
+		value, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+		position.Value = value
+	}
+	return position, nil
+}
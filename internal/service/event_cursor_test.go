@@ -0,0 +1,118 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"github.com/gofrs/uuid"
+)
+
+func TestEventCursorRoundTrip(t *testing.T) {
+	id := uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	at := time.Date(2025, 3, 1, 9, 30, 0, 123456000, time.FixedZone("EAT", 3*60*60))
+
+	tests := []struct {
+		sort  string
+		value any
+		want  any
+	}{
+		{"start_time", at, at.UTC()},
+		{"-start_time", at, at.UTC()},
+		{"created_at", at, at.UTC()},
+		{"-created_at", at, at.UTC()},
+		{"title", "Go Conference", "Go Conference"},
+		{"-title", "Shir Beesha", "Shir Beesha"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			encoded := encodeEventCursor(tt.sort, &repository.EventCursor{Value: tt.value, ID: id})
+
+			decoded, err := decodeEventCursor(tt.sort, encoded)
+			if err != nil {
+				t.Fatalf("decodeEventCursor returned %v", err)
+			}
+			if decoded.ID != id {
+				t.Errorf("ID = %s, want %s", decoded.ID, id)
+			}
+
+			switch want := tt.want.(type) {
+			case time.Time:
+				got, ok := decoded.Value.(time.Time)
+				if !ok || !got.Equal(want) || got.Location() != time.UTC {
+					t.Errorf("Value = %v, want %v in UTC", decoded.Value, want)
+				}
+			default:
+				if decoded.Value != want {
+					t.Errorf("Value = %v, want %v", decoded.Value, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeEventCursorRejectsOtherSort(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name, issued, replayed string
+		value                  any
+	}{
+		{"other column", "start_time", "created_at", at},
+		{"other direction", "start_time", "-start_time", at},
+		{"time cursor as title", "start_time", "title", at},
+		{"title cursor as time", "title", "start_time", "Go Conference"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeEventCursor(tt.issued, &repository.EventCursor{Value: tt.value, ID: id})
+			if _, err := decodeEventCursor(tt.replayed, encoded); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeEventCursor error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestDecodeEventCursorRejectsMalformed(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name, sort, cursor string
+	}{
+		{"not base64", "start_time", "%%%"},
+		{"padded base64", "start_time", base64.URLEncoding.EncodeToString([]byte(`{"s":"start_time"}`))},
+		{"not JSON", "start_time", encode("start_time,2025-03-01")},
+		{"missing ID", "title", encode(`{"s":"title","v":"Go"}`)},
+		{"bad ID", "title", encode(`{"s":"title","v":"Go","id":"42"}`)},
+		{"bad time", "start_time", encode(`{"s":"start_time","v":"yesterday","id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`)},
+		{"empty", "start_time", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeEventCursor(tt.sort, tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeEventCursor error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestParseEventSort(t *testing.T) {
+	tests := []struct {
+		sort       string
+		order      string
+		descending bool
+	}{
+		{"start_time", "start_time", false},
+		{"-start_time", "start_time", true},
+		{"-title", "title", true},
+	}
+	for _, tt := range tests {
+		order, descending := parseEventSort(tt.sort)
+		if order != tt.order || descending != tt.descending {
+			t.Errorf("parseEventSort(%q) = %q, %v; want %q, %v", tt.sort, order, descending, tt.order, tt.descending)
+		}
+	}
+}
@@ -1,31 +1,110 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
 	"time"
 
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
 	"github.com/gofrs/uuid"
 )
 
+var (
+	// ErrInvalidSchedule is returned when an event's start/end times or time zone are unusable
+	ErrInvalidSchedule = apperror.NewValidation("invalid event schedule")
+
+	// ErrInvalidTransition is returned when an event cannot move to the requested status
+	ErrInvalidTransition = apperror.NewConflict("invalid event status transition")
+
+	// ErrRegistrationClosed is returned when signing up for an event that is not accepting registrations
+	ErrRegistrationClosed = apperror.NewConflict("event is not open for registration")
+
+	// ErrCapacityBelowConfirmed is returned when an event's capacity is lowered below its confirmed registrations
+	ErrCapacityBelowConfirmed = apperror.NewConflict("capacity is below the confirmed registrations")
+
+	// ErrForbidden is returned when a user acts on an event they do not organize
+	ErrForbidden = apperror.NewForbidden("you are not allowed to modify this event")
+)
+
+// Page sizes of event listings
+const (
+	defaultEventPageSize = 20
+	maxEventPageSize     = 100
+)
+
+// EventListQuery selects a page of events. Sort is one of the
+// repository.EventSort orders, prefixed with "-" to sort descending, and
+// Cursor is the NextCursor of the previous page. The other fields filter as
+// in repository.EventFilter, including who the listing is for.
+type EventListQuery struct {
+	Status      string
+	OrganizerID uuid.UUID
+	Location    string
+	From        *time.Time
+	To          *time.Time
+	IsPublic    *bool
+	Search      string
+	ViewerID    uuid.UUID
+	ViewAll     bool
+	Sort        string
+	Cursor      string
+	Limit       int
+}
+
+// EventList is one page of events. NextCursor is empty on the last page.
+type EventList struct {
+	Events     []*entity.Event
+	NextCursor string
+	Total      int
+}
+
 type EventService interface {
-	CreateEvent(title, description, location string, capacity int, status string, OrganizerID uuid.UUID) (*entity.Event, error)
-	UpdateEvent(event *entity.Event) error
-	DeleteEvent(eventID uuid.UUID) error
-	GetEventByID(eventID uuid.UUID) (*entity.Event, error)
-	ListEvent() ([]*entity.Event, error)
+	CreateEvent(ctx context.Context, title, description, location string, startTime, endTime time.Time, timeZone string, capacity int, status string, isPublic bool, OrganizerID uuid.UUID) (*entity.Event, error)
+	UpdateEvent(ctx context.Context, userID uuid.UUID, event *entity.Event) error
+	DeleteEvent(ctx context.Context, userID, eventID uuid.UUID) error
+	GetEventByID(ctx context.Context, userID, eventID uuid.UUID) (*entity.Event, error)
+	ListEvents(ctx context.Context, query EventListQuery) (*EventList, error)
+	ListDeletedEvents(ctx context.Context) ([]*entity.Event, error)
+	RestoreEvent(ctx context.Context, eventID uuid.UUID) error
+	TransitionEvent(ctx context.Context, userID, eventID uuid.UUID, status string) (*entity.Event, error)
+	ListCoOrganizers(ctx context.Context, userID, eventID uuid.UUID) ([]uuid.UUID, error)
+	AddCoOrganizer(ctx context.Context, userID, eventID, coOrganizerID uuid.UUID) error
+	RemoveCoOrganizer(ctx context.Context, userID, eventID, coOrganizerID uuid.UUID) error
+	RegisterForEvent(ctx context.Context, eventID, userID uuid.UUID) (*entity.Registration, error)
+	CancelRegistration(ctx context.Context, eventID, userID uuid.UUID) error
+	GetRegistration(ctx context.Context, eventID, userID uuid.UUID) (*entity.Registration, error)
+	ListRegistrations(ctx context.Context, userID, eventID uuid.UUID) ([]*entity.Registration, error)
 }
 
 // userServiceImpl is the implementation of UserService.
 type EventServiceImpl struct {
-	repo      repository.EventRepository
-	tokenRepo repository.TokenRepository
+	repo             repository.EventRepository
+	registrationRepo repository.RegistrationRepository
+	tokenRepo        repository.TokenRepository
+	permissionRepo   repository.PermissionRepository
+	notifier         Notifier
+	uow              repository.UnitOfWork
 }
 
 // CreateEvent implements eventService.
-func (s *EventServiceImpl) CreateEvent(title string, description string, location string, capacity int, status string, OrganizerID uuid.UUID) (*entity.Event, error) {
+func (s *EventServiceImpl) CreateEvent(ctx context.Context, title string, description string, location string, startTime, endTime time.Time, timeZone string, capacity int, status string, isPublic bool, OrganizerID uuid.UUID) (*entity.Event, error) {
+	loc, err := validateSchedule(startTime, endTime, timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	// New events start as drafts unless the caller publishes them straight away
+	if status == "" {
+		status = entity.EventStatusDraft
+	}
+	if status != entity.EventStatusDraft && !entity.CanTransitionEventStatus(entity.EventStatusDraft, status) {
+		return nil, apperror.Wrap(apperror.Validation, `events cannot be created as "{0}"`, ErrInvalidTransition).WithParams(status)
+	}
+
 	// Generate a new UUID for the event ID
 	neoEvent, err := uuid.NewV4()
 	if err != nil {
@@ -37,79 +116,527 @@ func (s *EventServiceImpl) CreateEvent(title string, description string, locatio
 		Title:       title,
 		Description: description,
 		Location:    location,
-		StartTime:   time.Now(),
-		EndTime:     time.Now(),
+		StartTime:   startTime.In(loc),
+		EndTime:     endTime.In(loc),
+		TimeZone:    loc.String(),
 		Capacity:    capacity,
-		IsPublic:    true,
+		IsPublic:    isPublic,
 		Status:      status,
 		OrganizerID: OrganizerID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 	// Log the new event creation attempt
-	log.Printf("create Event: %+v", newEvent)
+	logger.FromContext(ctx).Info("Creating event", "event_id", newEvent.ID, "title", newEvent.Title, "organizer_id", newEvent.OrganizerID)
 
 	// Save the new event to the repository
-	err = s.repo.Create(newEvent)
-	if err != nil {
-		log.Printf("failed to create event: %v", err)
+	if err := s.repo.Create(ctx, newEvent); err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
 	}
 	return newEvent, nil
-
 }
 
 // DeleteEvent implements eventService.
-func (s *EventServiceImpl) DeleteEvent(eventID uuid.UUID) error {
-	_, err := s.repo.GetByID(eventID)
+func (s *EventServiceImpl) DeleteEvent(ctx context.Context, userID, eventID uuid.UUID) error {
+	event, err := s.repo.GetByID(ctx, eventID)
 	if err != nil {
-		return fmt.Errorf("could not find event with ID %s: %v", eventID, err)
+		return fmt.Errorf("could not find event with ID %s: %w", eventID, err)
 	}
 
-	if err := s.repo.Delete(eventID); err != nil {
-		return fmt.Errorf("failed to delete event with ID %s: %v", eventID, err)
+	if err := s.authorizeOrganizer(ctx, userID, event); err != nil {
+		return err
 	}
 
-	log.Printf("Successfully deleted event with ID %s", eventID)
+	if err := s.repo.Delete(ctx, eventID); err != nil {
+		return fmt.Errorf("failed to delete event with ID %s: %w", eventID, err)
+	}
+
+	logger.FromContext(ctx).Info("Successfully deleted event", "event_id", eventID)
 	return nil
 }
 
 // GetEventByID implements eventService.
-func (s *EventServiceImpl) GetEventByID(eventID uuid.UUID) (*entity.Event, error) {
-	event, err := s.repo.GetByID(eventID)
+func (s *EventServiceImpl) GetEventByID(ctx context.Context, userID, eventID uuid.UUID) (*entity.Event, error) {
+	event, err := s.findVisibleEvent(ctx, userID, eventID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get event with ID %s: %v", eventID, err)
+		return nil, fmt.Errorf("failed to get event with ID %s: %w", eventID, err)
 	}
 
-	return event, nil
+	return localizeEvent(ctx, event), nil
 }
 
-// ListEvent implements eventService.
-func (s *EventServiceImpl) ListEvent() ([]*entity.Event, error) {
-	events, err := s.repo.GetAll()
+// findVisibleEvent returns an event the user may see: a public event that is
+// not a draft, one they organize or co-organize, or any event for an
+// administrator. Other events are reported as not found, so their IDs
+// reveal nothing.
+func (s *EventServiceImpl) findVisibleEvent(ctx context.Context, userID, eventID uuid.UUID) (*entity.Event, error) {
+	event, err := s.repo.GetVisibleByID(ctx, eventID, userID)
+	if !errors.Is(err, repository.ErrEventNotFound) {
+		return event, err
+	}
+
+	admin, adminErr := s.isEventAdmin(ctx, userID)
+	if adminErr != nil {
+		return nil, adminErr
+	}
+	if !admin {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, eventID)
+}
+
+// ListEvents implements eventService.
+func (s *EventServiceImpl) ListEvents(ctx context.Context, query EventListQuery) (*EventList, error) {
+	// Events are listed soonest first unless asked otherwise
+	if query.Sort == "" {
+		query.Sort = repository.EventSortStartTime
+	}
+	order, descending := parseEventSort(query.Sort)
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultEventPageSize
+	}
+	limit = min(limit, maxEventPageSize)
+
+	filter := repository.EventFilter{
+		Status:      query.Status,
+		OrganizerID: query.OrganizerID,
+		Location:    query.Location,
+		From:        query.From,
+		To:          query.To,
+		IsPublic:    query.IsPublic,
+		Search:      query.Search,
+		ViewerID:    query.ViewerID,
+		ViewAll:     query.ViewAll,
+		Sort:        order,
+		Descending:  descending,
+		Limit:       limit,
+	}
+	if query.Cursor != "" {
+		after, err := decodeEventCursor(query.Sort, query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	page, err := s.repo.List(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all event: %v", err)
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	for _, event := range page.Events {
+		localizeEvent(ctx, event)
+	}
+
+	list := &EventList{Events: page.Events, Total: page.Total}
+	if page.Next != nil {
+		list.NextCursor = encodeEventCursor(query.Sort, page.Next)
+	}
+	return list, nil
+}
+
+// ListDeletedEvents implements eventService.
+func (s *EventServiceImpl) ListDeletedEvents(ctx context.Context) ([]*entity.Event, error) {
+	events, err := s.repo.ListDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted events: %w", err)
+	}
+
+	for _, event := range events {
+		localizeEvent(ctx, event)
 	}
 
 	return events, nil
 }
 
+// RestoreEvent implements eventService.
+func (s *EventServiceImpl) RestoreEvent(ctx context.Context, eventID uuid.UUID) error {
+	if err := s.repo.Restore(ctx, eventID); err != nil {
+		return fmt.Errorf("failed to restore event %s: %w", eventID, err)
+	}
+
+	logger.FromContext(ctx).Info("Restored event", "event_id", eventID)
+	return nil
+}
+
 // UpdateEvent implements eventService.
-func (s *EventServiceImpl) UpdateEvent(event *entity.Event) error {
-	_, err := s.repo.GetByID(event.ID)
+func (s *EventServiceImpl) UpdateEvent(ctx context.Context, userID uuid.UUID, event *entity.Event) error {
+	loc, err := validateSchedule(event.StartTime, event.EndTime, event.TimeZone)
 	if err != nil {
-		return fmt.Errorf("could not find event with ID %s", event.ID)
+		return err
 	}
+	event.TimeZone = loc.String()
+
+	// The event stays locked until the update is written, so a capacity
+	// change cannot race with registrations taking or freeing seats
+	var promoted []*entity.Registration
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.LockByID(ctx, event.ID)
+		if err != nil {
+			return fmt.Errorf("could not find event with ID %s: %w", event.ID, err)
+		}
 
-	if err := s.repo.Update(event); err != nil {
-		return fmt.Errorf("failed to update event with ID %s: %v", event.ID, err)
+		if err := s.authorizeOrganizer(ctx, userID, existing); err != nil {
+			return err
+		}
+
+		// Ownership cannot be changed through an update
+		event.OrganizerID = existing.OrganizerID
+
+		// Status only changes through TransitionEvent
+		if event.Status != "" && event.Status != existing.Status {
+			return apperror.Wrap(apperror.Validation, "status must be changed through the transition endpoints", ErrInvalidTransition)
+		}
+		event.Status = existing.Status
+
+		confirmed, err := s.registrationRepo.CountByStatus(ctx, event.ID, entity.RegistrationStatusConfirmed)
+		if err != nil {
+			return fmt.Errorf("failed to count registrations for event %s: %w", event.ID, err)
+		}
+		if event.Capacity < confirmed {
+			return fmt.Errorf("%w: %d seats are already confirmed", ErrCapacityBelowConfirmed, confirmed)
+		}
+
+		if err := s.repo.Update(ctx, event); err != nil {
+			return fmt.Errorf("failed to update event with ID %s: %w", event.ID, err)
+		}
+
+		// Seats added by a larger capacity go to the waitlist in order
+		promoted = nil
+		if event.Capacity > existing.Capacity {
+			for seats := event.Capacity - confirmed; seats > 0; seats-- {
+				registration, err := s.registrationRepo.PromoteNext(ctx, event.ID)
+				if err != nil {
+					return fmt.Errorf("failed to promote waitlisted registration for event %s: %w", event.ID, err)
+				}
+				if registration == nil {
+					break
+				}
+				promoted = append(promoted, registration)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
+	for _, registration := range promoted {
+		s.notifyPromotion(ctx, registration)
+	}
 	return nil
 }
 
-func NewEventService(eventRepo repository.EventRepository, tokenRepo repository.TokenRepository) EventService {
+// TransitionEvent implements eventService.
+func (s *EventServiceImpl) TransitionEvent(ctx context.Context, userID, eventID uuid.UUID, status string) (*entity.Event, error) {
+	event, err := s.repo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find event with ID %s: %w", eventID, err)
+	}
+
+	if err := s.authorizeOrganizer(ctx, userID, event); err != nil {
+		return nil, err
+	}
+
+	if !entity.CanTransitionEventStatus(event.Status, status) {
+		return nil, fmt.Errorf("%w: cannot move event from %q to %q", ErrInvalidTransition, event.Status, status)
+	}
+
+	if err := s.repo.UpdateStatus(ctx, eventID, event.Status, status); err != nil {
+		return nil, fmt.Errorf("failed to move event %s to %s: %w", eventID, status, err)
+	}
+
+	event.Status = status
+	return localizeEvent(ctx, event), nil
+}
+
+// ListCoOrganizers implements eventService.
+func (s *EventServiceImpl) ListCoOrganizers(ctx context.Context, userID, eventID uuid.UUID) ([]uuid.UUID, error) {
+	if _, err := s.findVisibleEvent(ctx, userID, eventID); err != nil {
+		return nil, fmt.Errorf("could not find event with ID %s: %w", eventID, err)
+	}
+
+	userIDs, err := s.repo.ListCoOrganizers(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get co-organizers for event %s: %w", eventID, err)
+	}
+
+	return userIDs, nil
+}
+
+// AddCoOrganizer implements eventService.
+func (s *EventServiceImpl) AddCoOrganizer(ctx context.Context, userID, eventID, coOrganizerID uuid.UUID) error {
+	event, err := s.repo.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("could not find event with ID %s: %w", eventID, err)
+	}
+
+	if err := s.authorizeOwner(ctx, userID, event); err != nil {
+		return err
+	}
+
+	if err := s.repo.AddCoOrganizer(ctx, eventID, coOrganizerID); err != nil {
+		return fmt.Errorf("failed to add co-organizer to event %s: %w", eventID, err)
+	}
+
+	return nil
+}
+
+// RemoveCoOrganizer implements eventService.
+func (s *EventServiceImpl) RemoveCoOrganizer(ctx context.Context, userID, eventID, coOrganizerID uuid.UUID) error {
+	event, err := s.repo.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("could not find event with ID %s: %w", eventID, err)
+	}
+
+	if err := s.authorizeOwner(ctx, userID, event); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveCoOrganizer(ctx, eventID, coOrganizerID); err != nil {
+		return fmt.Errorf("failed to remove co-organizer from event %s: %w", eventID, err)
+	}
+
+	return nil
+}
+
+// authorizeOwner allows the event's organizer and event admins.
+func (s *EventServiceImpl) authorizeOwner(ctx context.Context, userID uuid.UUID, event *entity.Event) error {
+	if event.OrganizerID == userID {
+		return nil
+	}
+
+	isAdmin, err := s.isEventAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// authorizeOrganizer allows the event's organizer, its co-organizers and event admins.
+func (s *EventServiceImpl) authorizeOrganizer(ctx context.Context, userID uuid.UUID, event *entity.Event) error {
+	err := s.authorizeOwner(ctx, userID, event)
+	if err != ErrForbidden {
+		return err
+	}
+
+	coOrganizers, err := s.repo.ListCoOrganizers(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get co-organizers for event %s: %w", event.ID, err)
+	}
+
+	for _, coOrganizerID := range coOrganizers {
+		if coOrganizerID == userID {
+			return nil
+		}
+	}
+
+	return ErrForbidden
+}
+
+// isEventAdmin reports whether the user may modify events they do not organize.
+func (s *EventServiceImpl) isEventAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	permissions, err := s.permissionRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get permissions for user %s: %w", userID, err)
+	}
+
+	for _, permission := range permissions {
+		if permission == entity.PermissionEventsAdmin {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// validateSchedule checks that an event has a start and end time in the right
+// order and resolves its IANA time zone, defaulting to UTC when none is given.
+func validateSchedule(startTime, endTime time.Time, timeZone string) (*time.Location, error) {
+	if startTime.IsZero() || endTime.IsZero() {
+		return nil, fmt.Errorf("%w: start and end time are required", ErrInvalidSchedule)
+	}
+
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("%w: end time must be after start time", ErrInvalidSchedule)
+	}
+
+	if timeZone == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidSchedule, timeZone)
+	}
+
+	return loc, nil
+}
+
+// localizeEvent renders an event's stored UTC times in its own time zone.
+func localizeEvent(ctx context.Context, event *entity.Event) *entity.Event {
+	loc, err := time.LoadLocation(event.TimeZone)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Unknown time zone", "time_zone", event.TimeZone, "event_id", event.ID, "error", err)
+		return event
+	}
+
+	event.StartTime = event.StartTime.In(loc)
+	event.EndTime = event.EndTime.In(loc)
+	return event
+}
+
+// RegisterForEvent implements eventService.
+func (s *EventServiceImpl) RegisterForEvent(ctx context.Context, eventID, userID uuid.UUID) (*entity.Registration, error) {
+	registrationID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	registration := &entity.Registration{
+		ID:        registrationID,
+		EventID:   eventID,
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// The event stays locked until the registration is written, so
+	// concurrent sign-ups cannot push the seat count past the capacity
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		event, err := s.repo.LockByID(ctx, eventID)
+		if err != nil {
+			return fmt.Errorf("could not find event with ID %s: %w", eventID, err)
+		}
+
+		if event.Status != entity.EventStatusRegistrationOpen {
+			return ErrRegistrationClosed
+		}
+
+		confirmed, err := s.registrationRepo.CountByStatus(ctx, eventID, entity.RegistrationStatusConfirmed)
+		if err != nil {
+			return fmt.Errorf("failed to count registrations for event %s: %w", eventID, err)
+		}
+
+		// Once every seat is taken the user joins the end of the waitlist
+		registration.Status = entity.RegistrationStatusConfirmed
+		registration.Position = 0
+		if confirmed >= event.Capacity {
+			waitlisted, err := s.registrationRepo.CountByStatus(ctx, eventID, entity.RegistrationStatusWaitlisted)
+			if err != nil {
+				return fmt.Errorf("failed to count registrations for event %s: %w", eventID, err)
+			}
+			registration.Status = entity.RegistrationStatusWaitlisted
+			registration.Position = waitlisted + 1
+		}
+
+		if err := s.registrationRepo.Create(ctx, registration); err != nil {
+			return fmt.Errorf("failed to register for event %s: %w", eventID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("User registered for event with status", "user_id", userID, "event_id", eventID, "status", registration.Status)
+	return registration, nil
+}
+
+// CancelRegistration implements eventService.
+// When a confirmed seat is released the oldest waitlisted registration is
+// promoted in the same transaction, and notified once it has committed.
+func (s *EventServiceImpl) CancelRegistration(ctx context.Context, eventID, userID uuid.UUID) error {
+	var promoted *entity.Registration
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Serialize with RegisterForEvent so the freed seat cannot be taken twice
+		if _, err := s.repo.LockByID(ctx, eventID); err != nil {
+			return fmt.Errorf("could not find event with ID %s: %w", eventID, err)
+		}
+
+		registration, err := s.registrationRepo.Delete(ctx, eventID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to cancel registration for event %s: %w", eventID, err)
+		}
+
+		promoted = nil
+		if registration.Status == entity.RegistrationStatusConfirmed {
+			promoted, err = s.registrationRepo.PromoteNext(ctx, eventID)
+			if err != nil {
+				return fmt.Errorf("failed to promote waitlisted registration for event %s: %w", eventID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("User cancelled registration", "user_id", userID, "event_id", eventID)
+
+	if promoted != nil {
+		s.notifyPromotion(ctx, promoted)
+	}
+	return nil
+}
+
+// notifyPromotion tells a user they moved off the waitlist. Delivery failures
+// are logged but do not undo the promotion.
+func (s *EventServiceImpl) notifyPromotion(ctx context.Context, registration *entity.Registration) {
+	title := registration.EventID.String()
+	if event, err := s.repo.GetByID(ctx, registration.EventID); err == nil {
+		title = event.Title
+	}
+
+	message := fmt.Sprintf("A seat has opened up and your registration for %q is now confirmed.", title)
+	if err := s.notifier.Notify(ctx, registration.UserID, "You're off the waitlist", message); err != nil {
+		logger.FromContext(ctx).Error("Failed to notify user of promotion", "user_id", registration.UserID, "error", err)
+	}
+}
+
+// GetRegistration implements eventService.
+func (s *EventServiceImpl) GetRegistration(ctx context.Context, eventID, userID uuid.UUID) (*entity.Registration, error) {
+	registration, err := s.registrationRepo.FindByEventAndUser(ctx, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration for event %s: %w", eventID, err)
+	}
+
+	return registration, nil
+}
+
+// ListRegistrations implements eventService.
+func (s *EventServiceImpl) ListRegistrations(ctx context.Context, userID, eventID uuid.UUID) ([]*entity.Registration, error) {
+	event, err := s.repo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find event with ID %s: %w", eventID, err)
+	}
+
+	if err := s.authorizeOrganizer(ctx, userID, event); err != nil {
+		return nil, err
+	}
+
+	registrations, err := s.registrationRepo.ListByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registrations for event %s: %w", eventID, err)
+	}
+
+	return registrations, nil
+}
+
+func NewEventService(eventRepo repository.EventRepository, registrationRepo repository.RegistrationRepository, tokenRepo repository.TokenRepository, permissionRepo repository.PermissionRepository, notifier Notifier, uow repository.UnitOfWork) EventService {
 	return &EventServiceImpl{
-		repo:      eventRepo,
-		tokenRepo: tokenRepo,
+		repo:             eventRepo,
+		registrationRepo: registrationRepo,
+		tokenRepo:        tokenRepo,
+		permissionRepo:   permissionRepo,
+		notifier:         notifier,
+		uow:              uow,
 	}
 }
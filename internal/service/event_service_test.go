@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"github.com/gofrs/uuid"
+)
+
+// visibilityEventRepo serves one event, visible only to the users in visibleTo
+type visibilityEventRepo struct {
+	repository.EventRepository
+	event     *entity.Event
+	visibleTo map[uuid.UUID]bool
+}
+
+func (r *visibilityEventRepo) GetByID(ctx context.Context, eventID uuid.UUID) (*entity.Event, error) {
+	if eventID != r.event.ID {
+		return nil, repository.ErrEventNotFound
+	}
+	copied := *r.event
+	return &copied, nil
+}
+
+func (r *visibilityEventRepo) GetVisibleByID(ctx context.Context, eventID, viewerID uuid.UUID) (*entity.Event, error) {
+	if !r.visibleTo[viewerID] {
+		return nil, repository.ErrEventNotFound
+	}
+	return r.GetByID(ctx, eventID)
+}
+
+func (r *visibilityEventRepo) ListCoOrganizers(ctx context.Context, eventID uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+// staticPermissionRepo grants events:admin to the users in admins
+type staticPermissionRepo struct {
+	admins map[uuid.UUID]bool
+}
+
+func (r *staticPermissionRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	if r.admins[userID] {
+		return []string{entity.PermissionEventsRead, entity.PermissionEventsAdmin}, nil
+	}
+	return []string{entity.PermissionEventsRead}, nil
+}
+
+func TestHiddenEventsAreNotFound(t *testing.T) {
+	organizer := uuid.Must(uuid.NewV4())
+	stranger := uuid.Must(uuid.NewV4())
+	admin := uuid.Must(uuid.NewV4())
+
+	draft := &entity.Event{
+		ID:          uuid.Must(uuid.NewV4()),
+		Status:      entity.EventStatusDraft,
+		OrganizerID: organizer,
+		TimeZone:    "UTC",
+	}
+	s := &EventServiceImpl{
+		repo:           &visibilityEventRepo{event: draft, visibleTo: map[uuid.UUID]bool{organizer: true}},
+		permissionRepo: &staticPermissionRepo{admins: map[uuid.UUID]bool{admin: true}},
+	}
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		visible bool
+	}{
+		{"organizer", organizer, true},
+		{"administrator", admin, true},
+		{"other user", stranger, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := s.GetEventByID(context.Background(), tt.userID, draft.ID)
+			if tt.visible {
+				if err != nil || event.ID != draft.ID {
+					t.Errorf("GetEventByID = %v, %v; want the event", event, err)
+				}
+			} else if !errors.Is(err, repository.ErrEventNotFound) {
+				t.Errorf("GetEventByID error = %v, want ErrEventNotFound", err)
+			}
+
+			_, err = s.ListCoOrganizers(context.Background(), tt.userID, draft.ID)
+			if tt.visible && err != nil {
+				t.Errorf("ListCoOrganizers error = %v, want none", err)
+			}
+			if !tt.visible && !errors.Is(err, repository.ErrEventNotFound) {
+				t.Errorf("ListCoOrganizers error = %v, want ErrEventNotFound", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+// ErrAccountLocked is returned when sign-in is refused because of too many failed attempts
+var ErrAccountLocked = apperror.NewTooManyRequests("too many failed sign-in attempts")
+
+// LockoutError reports until when sign-in is locked. It matches ErrAccountLocked with errors.Is.
+type LockoutError struct {
+	Until time.Time
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("%v, try again after %s", ErrAccountLocked, e.Until.UTC().Format(time.RFC3339))
+}
+
+func (e *LockoutError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// RetryAfter returns how long until sign-in is allowed again.
+func (e *LockoutError) RetryAfter() time.Duration {
+	return time.Until(e.Until)
+}
+
+// LoginLimiter tracks failed sign-ins per account and per IP address. Once a
+// key passes its threshold it is locked out, and every further failure
+// doubles the lockout up to the configured maximum.
+type LoginLimiter struct {
+	attempts repository.LoginAttemptRepository
+	audit    repository.AuditRepository
+	cfg      *config.LockoutConfig
+}
+
+// NewLoginLimiter creates a LoginLimiter backed by the given attempt store.
+func NewLoginLimiter(attempts repository.LoginAttemptRepository, audit repository.AuditRepository, cfg *config.LockoutConfig) *LoginLimiter {
+	return &LoginLimiter{attempts: attempts, audit: audit, cfg: cfg}
+}
+
+// Check returns a LockoutError when the account or the client's IP address is locked out.
+func (l *LoginLimiter) Check(ctx context.Context, email, ipAddress string) error {
+	now := time.Now()
+
+	var until time.Time
+	for _, key := range []string{accountKey(email), ipKey(ipAddress)} {
+		attempt, err := l.attempts.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to check login attempts: %w", err)
+		}
+		if attempt != nil && attempt.IsLocked(now) && attempt.LockedUntil.After(until) {
+			until = *attempt.LockedUntil
+		}
+	}
+
+	if !until.IsZero() {
+		return &LockoutError{Until: until}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed sign-in against the account and the IP address.
+// userID is nil when the email does not belong to an account.
+func (l *LoginLimiter) RecordFailure(ctx context.Context, email, ipAddress string, userID *uuid.UUID) {
+	l.recordFailure(ctx, accountKey(email), l.cfg.AccountThreshold, entity.AuditAccountLocked, userID, ipAddress)
+	l.recordFailure(ctx, ipKey(ipAddress), l.cfg.IPThreshold, entity.AuditIPLocked, nil, ipAddress)
+}
+
+// RecordSuccess clears the account's failed attempts. The IP address keeps its
+// count so one valid account cannot be used to reset it.
+func (l *LoginLimiter) RecordSuccess(ctx context.Context, email string) {
+	if err := l.attempts.Reset(ctx, accountKey(email)); err != nil {
+		logger.FromContext(ctx).Error("Failed to reset login attempts", "error", err)
+	}
+}
+
+// Unlock lifts an account lockout on behalf of an administrator.
+func (l *LoginLimiter) Unlock(ctx context.Context, email string, userID, actorID uuid.UUID) error {
+	if err := l.attempts.Reset(ctx, accountKey(email)); err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+
+	l.record(ctx, &entity.AuditEvent{Type: entity.AuditAccountUnlocked, UserID: &userID, ActorID: &actorID})
+	return nil
+}
+
+// recordFailure counts one failure for key and locks it once it reaches threshold.
+func (l *LoginLimiter) recordFailure(ctx context.Context, key string, threshold int, auditType string, userID *uuid.UUID, ipAddress string) {
+	failures, err := l.attempts.RecordFailure(ctx, key, l.cfg.FailureWindow)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to record failed login", "error", err)
+		return
+	}
+
+	if failures < threshold {
+		return
+	}
+
+	duration := l.lockoutDuration(failures - threshold)
+	if err := l.attempts.Lock(ctx, key, time.Now().Add(duration)); err != nil {
+		logger.FromContext(ctx).Error("Failed to lock", "key", key, "error", err)
+		return
+	}
+
+	logger.FromContext(ctx).Warn("Locked out after failed sign-ins", "key", key, "duration", duration, "failures", failures)
+	l.record(ctx, &entity.AuditEvent{
+		Type:      auditType,
+		UserID:    userID,
+		IPAddress: ipAddress,
+		Detail:    fmt.Sprintf("key=%s failures=%d locked_for=%s", key, failures, duration),
+	})
+}
+
+// lockoutDuration doubles the base lockout for every failure past the threshold.
+func (l *LoginLimiter) lockoutDuration(excess int) time.Duration {
+	duration := l.cfg.BaseLockout
+	for i := 0; i < excess && duration < l.cfg.MaxLockout; i++ {
+		duration *= 2
+	}
+	return min(duration, l.cfg.MaxLockout)
+}
+
+// record writes an audit event; a failure to audit does not block sign-in handling.
+func (l *LoginLimiter) record(ctx context.Context, event *entity.AuditEvent) {
+	if err := l.audit.Create(ctx, event); err != nil {
+		logger.FromContext(ctx).Error("Failed to record audit event", "type", event.Type, "error", err)
+	}
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
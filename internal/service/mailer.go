@@ -0,0 +1,8 @@
+package service
+
+import "context"
+
+// Mailer sends email messages
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
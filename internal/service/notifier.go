@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// Notifier delivers messages to users
+type Notifier interface {
+	Notify(ctx context.Context, userID uuid.UUID, subject, message string) error
+}
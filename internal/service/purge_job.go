@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+)
+
+// PurgeJob permanently removes soft-deleted users and events, and tokens
+// that were revoked or expired, once they are past the retention window.
+type PurgeJob struct {
+	users     repository.UserRepository
+	events    repository.EventRepository
+	tokens    repository.TokenRepository
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewPurgeJob creates a PurgeJob.
+func NewPurgeJob(users repository.UserRepository, events repository.EventRepository, tokens repository.TokenRepository, cfg *config.PurgeConfig) *PurgeJob {
+	return &PurgeJob{
+		users:     users,
+		events:    events,
+		tokens:    tokens,
+		retention: cfg.Retention,
+		interval:  cfg.Interval,
+	}
+}
+
+// Run purges once straight away and then on every interval until ctx is done.
+func (j *PurgeJob) Run(ctx context.Context) {
+	if j.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.Purge(ctx); err != nil {
+			logger.FromContext(ctx).Error("Purge failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge removes every record past the retention window.
+func (j *PurgeJob) Purge(ctx context.Context) error {
+	cutoff := time.Now().Add(-j.retention)
+
+	events, err := j.events.PurgeDeleted(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge deleted events: %v", err)
+	}
+
+	// Events go first so organizers whose events were all deleted can follow
+	users, err := j.users.PurgeDeleted(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge deleted users: %v", err)
+	}
+
+	tokens, err := j.tokens.PurgeStale(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge stale tokens: %v", err)
+	}
+
+	if events+users+tokens > 0 {
+		logger.FromContext(ctx).Info("Purged deleted records", "events", events, "users", users, "tokens", tokens, "cutoff", cutoff)
+	}
+	return nil
+}
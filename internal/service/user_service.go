@@ -1,72 +1,220 @@
 package service
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
 	"errors"
 	"fmt"
-	"log"
+	"strings"
 	"time"
 
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/entity"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/auth"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
 	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/utils"
 	"github.com/gofrs/uuid"
 )
 
+var (
+	// ErrInvalidCredentials is returned when the email is unknown or the password is wrong
+	ErrInvalidCredentials = apperror.NewUnauthorized("invalid email or password")
+
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or revoked
+	ErrInvalidRefreshToken = apperror.NewUnauthorized("invalid or expired refresh token")
+
+	// ErrRefreshTokenReused is returned when an already exchanged refresh token is presented again
+	ErrRefreshTokenReused = apperror.NewUnauthorized("refresh token has already been used")
+
+	// ErrSessionNotFound is returned when signing out of a session the user does not have
+	ErrSessionNotFound = apperror.NewNotFound("session not found")
+
+	// ErrInvalidResetToken is returned when a password reset token is unknown, expired or already used
+	ErrInvalidResetToken = apperror.NewBadRequest("invalid or expired password reset token")
+
+	// ErrEmailNotVerified is returned when an account that has not confirmed its email tries to sign in
+	ErrEmailNotVerified = apperror.NewForbidden("email address has not been verified")
+
+	// ErrInvalidVerificationToken is returned when a verification token is unknown, expired or already used
+	ErrInvalidVerificationToken = apperror.NewBadRequest("invalid or expired verification token")
+
+	// ErrTwoFactorAlreadyEnabled is returned when enrolling a user who already has two-factor authentication
+	ErrTwoFactorAlreadyEnabled = apperror.NewConflict("two-factor authentication is already enabled")
+
+	// ErrTwoFactorNotEnabled is returned when managing two-factor authentication the user has not set up
+	ErrTwoFactorNotEnabled = apperror.NewNotFound("two-factor authentication is not enabled")
+
+	// ErrInvalidTwoFactorCode is returned when an authenticator or recovery code is wrong or already used
+	ErrInvalidTwoFactorCode = apperror.NewUnauthorized("invalid two-factor code")
+
+	// ErrInvalidChallengeToken is returned when a login challenge is unknown, expired or already completed
+	ErrInvalidChallengeToken = apperror.NewUnauthorized("invalid or expired login challenge")
+)
+
+// recoveryCodeCount is how many recovery codes are issued at a time
+const recoveryCodeCount = 10
+
+// ClientInfo describes the device a session was started from
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// LoginResult is returned by a successful authentication or token refresh
+type LoginResult struct {
+	AccessToken      string       `json:"access_token"`
+	TokenType        string       `json:"token_type"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	ExpiresIn        int64        `json:"expires_in"`
+	RefreshToken     string       `json:"refresh_token"`
+	RefreshExpiresAt time.Time    `json:"refresh_expires_at"`
+	User             *entity.User `json:"user"`
+}
+
+// TwoFactorChallenge is returned instead of tokens when the password was
+// correct but the account also requires a second factor
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	ChallengeToken    string    `json:"challenge_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// TwoFactorSetup carries what the user needs to add the account to an authenticator app
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
 type UserService interface {
-	RegisterUser(username, email, password, first_name, last_name string) (*entity.User, error)
-	UpdateUser(user *entity.User) error
+	RegisterUser(ctx context.Context, username, email, password, first_name, last_name string) (*entity.User, error)
+	UpdateUser(ctx context.Context, user *entity.User) error
 	// DeactivateUser(userID uint) error
 	// ActivateUser(userID uint) error
-	DeleteUser(userID uuid.UUID) error
-	GetUserByID(userID uuid.UUID) (*entity.User, error)
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
 	// GetUserByEmail(email string) (*entity.User, error)
-	ListUsers() ([]*entity.User, error)
-	AuthenticateUser(email, password string) (*entity.User, error)
+	ListUsers(ctx context.Context) ([]*entity.User, error)
+	ListDeletedUsers(ctx context.Context) ([]*entity.User, error)
+	RestoreUser(ctx context.Context, userID uuid.UUID) error
+	AuthenticateUser(ctx context.Context, email, password string, client ClientInfo) (*LoginResult, *TwoFactorChallenge, error)
+	CompleteTwoFactorLogin(ctx context.Context, challengeToken, code string, client ClientInfo) (*LoginResult, error)
+	RefreshToken(ctx context.Context, refreshToken string, client ClientInfo) (*LoginResult, error)
+	Logout(ctx context.Context, userID, sessionID uuid.UUID) error
+	LogoutAll(ctx context.Context, userID uuid.UUID) error
+	ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]*entity.Session, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, resetToken, newPassword string) error
+	VerifyEmail(ctx context.Context, verificationToken string) error
+	ResendVerification(ctx context.Context, email string) error
+	SetupTwoFactor(ctx context.Context, userID uuid.UUID) (*TwoFactorSetup, error)
+	ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	ListRoles(ctx context.Context, userID uuid.UUID) ([]*entity.Role, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, roleName string) error
+	RemoveRole(ctx context.Context, userID uuid.UUID, roleName string) error
+	UnlockUser(ctx context.Context, actorID, userID uuid.UUID) error
 }
 
 // userServiceImpl is the implementation of UserService.
 type UserServiceImpl struct {
-	repo      repository.UserRepository
-	tokenRepo repository.TokenRepository
+	repo             repository.UserRepository
+	tokenRepo        repository.TokenRepository
+	roleRepo         repository.RoleRepository
+	twoFactorRepo    repository.TwoFactorRepository
+	tokenManager     *auth.TokenManager
+	revocations      *auth.RevocationList
+	notifier         Notifier
+	mailer           Mailer
+	limiter          *LoginLimiter
+	uow              repository.UnitOfWork
+	refreshTokenTTL  time.Duration
+	passwordResetTTL time.Duration
+	resetInterval    time.Duration
+	verificationTTL  time.Duration
+	resendInterval   time.Duration
+	challengeTTL     time.Duration
+	issuer           string
 }
 
 // ListUsers implements UserService.
-func (s *UserServiceImpl) ListUsers() ([]*entity.User, error) {
-	users, err := s.repo.ListAll()
+func (s *UserServiceImpl) ListUsers(ctx context.Context) ([]*entity.User, error) {
+	users, err := s.repo.ListAll(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all users: %v", err)
+		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
 
 	return users, nil
 
 }
 
-// ListUsers implements UserService.
+// ListDeletedUsers implements UserService.
+func (s *UserServiceImpl) ListDeletedUsers(ctx context.Context) ([]*entity.User, error) {
+	users, err := s.repo.ListDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted users: %w", err)
+	}
+
+	return users, nil
+}
+
+// RestoreUser implements UserService. Sessions revoked by the delete stay
+// revoked, so the user has to sign in again.
+func (s *UserServiceImpl) RestoreUser(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.Restore(ctx, userID); err != nil {
+		return fmt.Errorf("failed to restore user %s: %w", userID, err)
+	}
+
+	logger.FromContext(ctx).Info("Restored user", "user_id", userID)
+	return nil
+}
 
 // GetUserByID implements UserService.
-func (s *UserServiceImpl) GetUserByID(userID uuid.UUID) (*entity.User, error) {
-	user, err := s.repo.FindByID(userID)
+func (s *UserServiceImpl) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	user, err := s.repo.FindByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user with ID %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to get user with ID %s: %w", userID, err)
 	}
 
 	return user, nil
 }
 
 // NewUserService creates a new UserService instance.
-func NewUserService(userRepo repository.UserRepository, tokenRepo repository.TokenRepository) UserService {
+func NewUserService(userRepo repository.UserRepository, tokenRepo repository.TokenRepository, roleRepo repository.RoleRepository, twoFactorRepo repository.TwoFactorRepository, tokenManager *auth.TokenManager, revocations *auth.RevocationList, notifier Notifier, mailer Mailer, limiter *LoginLimiter, uow repository.UnitOfWork, authConfig *config.AuthConfig) UserService {
 	return &UserServiceImpl{
-		repo:      userRepo,
-		tokenRepo: tokenRepo,
+		repo:             userRepo,
+		tokenRepo:        tokenRepo,
+		roleRepo:         roleRepo,
+		twoFactorRepo:    twoFactorRepo,
+		tokenManager:     tokenManager,
+		revocations:      revocations,
+		notifier:         notifier,
+		mailer:           mailer,
+		limiter:          limiter,
+		uow:              uow,
+		refreshTokenTTL:  authConfig.RefreshTokenTTL,
+		passwordResetTTL: authConfig.PasswordResetTTL,
+		resetInterval:    authConfig.PasswordResetInterval,
+		verificationTTL:  authConfig.VerificationTTL,
+		resendInterval:   authConfig.VerificationResendInterval,
+		challengeTTL:     authConfig.TwoFactorChallengeTTL,
+		issuer:           authConfig.Issuer,
 	}
 
 }
 
-func (s *UserServiceImpl) RegisterUser(username, email, password, first_name, last_name string) (*entity.User, error) {
+func (s *UserServiceImpl) RegisterUser(ctx context.Context, username, email, password, first_name, last_name string) (*entity.User, error) {
 
 	// Check if the user already exists
-	if _, err := s.repo.FindByEmail(email); err == nil {
-		return nil, fmt.Errorf("user already exists")
+	_, err := s.repo.FindByEmail(ctx, email)
+	if err == nil {
+		return nil, repository.ErrUserTaken
+	}
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to check for existing user: %w", err)
 	}
 
 	// Hash the password using bcrypt
@@ -75,95 +223,923 @@ func (s *UserServiceImpl) RegisterUser(username, email, password, first_name, la
 		return nil, err
 	}
 
-	// Create user
+	// Create user. The account stays inactive until the email is verified.
 	user := &entity.User{
 		Username:  username,
 		Email:     email,
 		Password:  HashPassword,
 		FirstName: first_name,
 		LastName:  last_name,
-		IsActive:  true,
+		IsActive:  false,
 	}
 
-	// Save the user to the repository
-	if err := s.repo.Create(user); err != nil {
+	// The user, their default role and their verification code are saved
+	// together so a failure part way through leaves no half-created account
+	var verificationToken string
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, user); err != nil {
+			return err
+		}
+
+		if err := s.roleRepo.AssignToUser(ctx, user.ID, entity.RoleUser); err != nil {
+			return fmt.Errorf("failed to assign default role: %w", err)
+		}
+
+		verificationToken, err = s.createVerificationToken(ctx, user)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	// The account already exists, so a delivery failure is not fatal; the user can ask for a resend
+	if err := s.mailVerification(ctx, user, verificationToken); err != nil {
+		logger.FromContext(ctx).Error("Failed to send verification email", "user_id", user.ID, "error", err)
+	}
+
 	return user, nil
 }
 
 // AuthenticateUser authenticates a user by email and password.
-func (s *UserServiceImpl) AuthenticateUser(email, password string) (*entity.User, error) {
+// When the user has two-factor authentication enabled no tokens are issued;
+// instead a challenge is returned that CompleteTwoFactorLogin exchanges for tokens.
+func (s *UserServiceImpl) AuthenticateUser(ctx context.Context, email, password string, client ClientInfo) (*LoginResult, *TwoFactorChallenge, error) {
+	// Refuse to check the password at all while the account or client is locked out
+	if err := s.limiter.Check(ctx, email, client.IPAddress); err != nil {
+		return nil, nil, err
+	}
+
 	// Find user by email
-	user, err := s.repo.FindByEmail(email)
+	user, err := s.repo.FindByEmail(ctx, email)
 	if err != nil {
-		return nil, errors.New("invalid email or password")
+		if !errors.Is(err, repository.ErrUserNotFound) {
+			return nil, nil, fmt.Errorf("failed to find user: %w", err)
+		}
+		s.limiter.RecordFailure(ctx, email, client.IPAddress, nil)
+		return nil, nil, ErrInvalidCredentials
 	}
 
 	// Check if the password is correct
 	if !utils.CheckPasswordHash(password, user.Password) {
-		return nil, errors.New("invalid email or password")
+		s.limiter.RecordFailure(ctx, email, client.IPAddress, &user.ID)
+		return nil, nil, ErrInvalidCredentials
 	}
 
-	// Generate token (UUID in this case, but you can replace it with JWT or any other mechanism)
-	newToken, err := uuid.NewV4()
+	if user.EmailVerifiedAt == nil {
+		return nil, nil, ErrEmailNotVerified
+	}
+
+	twoFactor, err := s.twoFactorRepo.FindByUser(ctx, user.ID)
+	if err != nil && !errors.Is(err, repository.ErrTwoFactorNotFound) {
+		return nil, nil, fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if twoFactor != nil && twoFactor.Enabled() {
+		challenge, err := s.issueChallenge(ctx, user, client)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, challenge, nil
+	}
+
+	// Every login starts a new refresh token family
+	familyID, err := uuid.NewV4()
+	if err != nil {
+		return nil, nil, errors.New("failed to generate token")
+	}
+
+	result, err := s.issueTokens(ctx, user, familyID, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.limiter.RecordSuccess(ctx, email)
+	return result, nil, nil
+}
+
+// CompleteTwoFactorLogin implements UserService. It finishes a login started
+// by AuthenticateUser using an authenticator or recovery code. A wrong code
+// leaves the challenge usable until it expires.
+func (s *UserServiceImpl) CompleteTwoFactorLogin(ctx context.Context, challengeToken, code string, client ClientInfo) (*LoginResult, error) {
+	token, err := s.tokenRepo.FindByToken(ctx, challengeToken)
+	if err != nil {
+		logger.FromContext(ctx).Info("Challenge token lookup failed", "error", err)
+		return nil, ErrInvalidChallengeToken
+	}
+
+	if token == nil || token.Type != entity.TokenTypeChallenge || token.UsedAt != nil || token.DeletedAt != nil || token.ExpiresAt.Before(time.Now()) {
+		return nil, ErrInvalidChallengeToken
+	}
+
+	user, err := s.repo.FindByID(ctx, token.UserID)
+	if err != nil {
+		return nil, ErrInvalidChallengeToken
+	}
+
+	// Wrong codes count towards the same lockout as wrong passwords
+	if err := s.limiter.Check(ctx, user.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := s.enabledTwoFactor(ctx, user.ID)
+	if err != nil {
+		return nil, ErrInvalidChallengeToken
+	}
+
+	if err := s.checkSecondFactor(ctx, twoFactor, code); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			s.limiter.RecordFailure(ctx, user.Email, client.IPAddress, &user.ID)
+		}
+		return nil, err
+	}
+
+	marked, err := s.tokenRepo.MarkUsed(ctx, token.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete login challenge: %w", err)
+	}
+	if !marked {
+		return nil, ErrInvalidChallengeToken
+	}
+
+	familyID, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+
+	result, err := s.issueTokens(ctx, user, familyID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	s.limiter.RecordSuccess(ctx, user.Email)
+	return result, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access and refresh token pair.
+// Presenting a refresh token a second time revokes its whole family, since it
+// means the token has leaked.
+func (s *UserServiceImpl) RefreshToken(ctx context.Context, refreshToken string, client ClientInfo) (*LoginResult, error) {
+	token, err := s.tokenRepo.FindByToken(ctx, refreshToken)
+	if err != nil {
+		logger.FromContext(ctx).Info("Refresh token lookup failed", "error", err)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if token == nil || token.Type != entity.TokenTypeRefresh || token.DeletedAt != nil || token.ExpiresAt.Before(time.Now()) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// The old token is consumed and its replacement issued together, so a
+	// failure part way through does not leave the session without a token
+	var result *LoginResult
+	reused := false
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		marked, err := s.tokenRepo.MarkUsed(ctx, token.ID)
+		if err != nil {
+			return fmt.Errorf("failed to rotate refresh token: %w", err)
+		}
+		if !marked {
+			reused = true
+			return nil
+		}
+
+		user, err := s.repo.FindByID(ctx, token.UserID)
+		if err != nil {
+			return ErrInvalidRefreshToken
+		}
+		// Sessions only continue for accounts that could still sign in
+		if !user.IsActive || user.EmailVerifiedAt == nil {
+			return ErrInvalidRefreshToken
+		}
+
+		result, err = s.issueTokens(ctx, user, token.FamilyID, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reused {
+		logger.FromContext(ctx).Warn("Refresh token reuse detected, revoking family", "user_id", token.UserID, "family_id", token.FamilyID)
+		if err := s.tokenRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+			logger.FromContext(ctx).Error("Failed to revoke token family", "family_id", token.FamilyID, "error", err)
+		}
+		s.revocations.MarkRevoked(token.FamilyID)
+		return nil, ErrRefreshTokenReused
+	}
+
+	return result, nil
+}
+
+// Logout implements UserService. It signs out a single session.
+func (s *UserServiceImpl) Logout(ctx context.Context, userID, sessionID uuid.UUID) error {
+	sessions, err := s.tokenRepo.ListSessions(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get sessions for user %s: %w", userID, err)
+	}
+
+	found := false
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrSessionNotFound
+	}
+
+	if err := s.tokenRepo.RevokeFamily(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session %s: %w", sessionID, err)
+	}
+	s.revocations.MarkRevoked(sessionID)
+
+	logger.FromContext(ctx).Info("User signed out", "user_id", userID, "session_id", sessionID)
+	return nil
+}
+
+// LogoutAll implements UserService. It signs the user out everywhere.
+func (s *UserServiceImpl) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	sessions, err := s.tokenRepo.ListSessions(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get sessions for user %s: %w", userID, err)
+	}
+
+	if err := s.tokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke tokens for user %s: %w", userID, err)
+	}
+
+	for _, session := range sessions {
+		s.revocations.MarkRevoked(session.ID)
+	}
+
+	logger.FromContext(ctx).Info("User signed out of all sessions", "user_id", userID, "count", len(sessions))
+	return nil
+}
+
+// ListSessions implements UserService.
+func (s *UserServiceImpl) ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]*entity.Session, error) {
+	sessions, err := s.tokenRepo.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions for user %s: %w", userID, err)
+	}
+
+	for _, session := range sessions {
+		session.Current = session.ID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+// RequestPasswordReset implements UserService. It sends a single-use reset
+// code to the account's owner. Unknown emails, requests made too soon after
+// the last reset and failures to deliver the code are not reported so the
+// endpoint does not reveal which addresses are registered.
+func (s *UserServiceImpl) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.repo.FindByEmail(ctx, email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		logger.FromContext(ctx).Info("Password reset requested for unknown email")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	last, err := s.tokenRepo.FindLatestByUser(ctx, user.ID, entity.TokenTypeReset)
+	if err != nil {
+		return fmt.Errorf("failed to check previous password reset: %w", err)
+	}
+	if last != nil && time.Since(last.CreatedAt) < s.resetInterval {
+		logger.FromContext(ctx).Info("Password reset throttled", "user_id", user.ID)
+		return nil
+	}
+
+	tokenID, err := uuid.NewV4()
+	if err != nil {
+		return errors.New("failed to generate token")
+	}
+
+	resetToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return errors.New("failed to generate token")
+	}
+
+	token := &entity.Token{
+		ID:        tokenID,
+		UserID:    user.ID,
+		Token:     resetToken,
+		Type:      entity.TokenTypeReset,
+		FamilyID:  tokenID,
+		ExpiresAt: time.Now().UTC().Add(s.passwordResetTTL),
+	}
+
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return errors.New("failed to save token")
+	}
+
+	message := fmt.Sprintf("Use this code to reset your password: %s\nIt expires in %s. If you did not ask for a reset, ignore this message.",
+		resetToken, s.passwordResetTTL)
+	if err := s.notifier.Notify(ctx, user.ID, "Reset your password", message); err != nil {
+		logger.FromContext(ctx).Error("Failed to send password reset", "user_id", user.ID, "error", err)
+	}
+
+	return nil
+}
+
+// ResetPassword implements UserService. A successful reset signs the user out everywhere.
+func (s *UserServiceImpl) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	token, err := s.tokenRepo.FindByToken(ctx, resetToken)
+	if err != nil {
+		logger.FromContext(ctx).Info("Reset token lookup failed", "error", err)
+		return ErrInvalidResetToken
+	}
+
+	if token == nil || token.Type != entity.TokenTypeReset || token.DeletedAt != nil || token.ExpiresAt.Before(time.Now()) {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	// The token is consumed, the password changed and every session revoked
+	// together, so no old session outlives a completed reset
+	var user *entity.User
+	var sessions []*entity.Session
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		// Consume the token before changing anything so it cannot be replayed
+		marked, err := s.tokenRepo.MarkUsed(ctx, token.ID)
+		if err != nil {
+			return fmt.Errorf("failed to consume reset token: %w", err)
+		}
+		if !marked {
+			return ErrInvalidResetToken
+		}
+
+		user, err = s.repo.FindByID(ctx, token.UserID)
+		if err != nil {
+			return ErrInvalidResetToken
+		}
+
+		user.Password = hashedPassword
+		if err := s.repo.Update(ctx, user); err != nil {
+			return fmt.Errorf("failed to update password: %w", err)
+		}
+
+		// Existing sessions and any other outstanding reset codes stop working
+		sessions, err = s.tokenRepo.ListSessions(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get sessions for user %s: %w", user.ID, err)
+		}
+		if err := s.tokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to revoke tokens for user %s: %w", user.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		s.revocations.MarkRevoked(session.ID)
+	}
+
+	logger.FromContext(ctx).Info("Password reset completed", "user_id", user.ID)
+	return nil
+}
+
+// VerifyEmail implements UserService. It confirms the user's email address and activates the account.
+func (s *UserServiceImpl) VerifyEmail(ctx context.Context, verificationToken string) error {
+	token, err := s.tokenRepo.FindByToken(ctx, verificationToken)
+	if err != nil {
+		logger.FromContext(ctx).Info("Verification token lookup failed", "error", err)
+		return ErrInvalidVerificationToken
+	}
+
+	if token == nil || token.Type != entity.TokenTypeVerification || token.DeletedAt != nil || token.ExpiresAt.Before(time.Now()) {
+		return ErrInvalidVerificationToken
+	}
+
+	marked, err := s.tokenRepo.MarkUsed(ctx, token.ID)
+	if err != nil {
+		return fmt.Errorf("failed to consume verification token: %w", err)
+	}
+	if !marked {
+		return ErrInvalidVerificationToken
+	}
+
+	user, err := s.repo.FindByID(ctx, token.UserID)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	verifiedAt := time.Now().UTC()
+	user.EmailVerifiedAt = &verifiedAt
+	user.IsActive = true
+	if err := s.repo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to verify user %s: %w", user.ID, err)
+	}
+
+	logger.FromContext(ctx).Info("Verified email", "user_id", user.ID)
+	return nil
+}
+
+// ResendVerification implements UserService. Unknown and already verified
+// emails, and requests made too soon after the last email, are ignored so the
+// endpoint does not reveal which addresses are registered.
+func (s *UserServiceImpl) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.repo.FindByEmail(ctx, email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	last, err := s.tokenRepo.FindLatestByUser(ctx, user.ID, entity.TokenTypeVerification)
+	if err != nil {
+		return fmt.Errorf("failed to check previous verification: %w", err)
+	}
+	if last != nil && time.Since(last.CreatedAt) < s.resendInterval {
+		logger.FromContext(ctx).Info("Verification resend throttled", "user_id", user.ID)
+		return nil
+	}
+
+	return s.sendVerification(ctx, user)
+}
+
+// sendVerification stores a new verification token and emails it to the user.
+func (s *UserServiceImpl) sendVerification(ctx context.Context, user *entity.User) error {
+	verificationToken, err := s.createVerificationToken(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	return s.mailVerification(ctx, user, verificationToken)
+}
+
+// createVerificationToken stores a new email verification code for the user and returns it.
+func (s *UserServiceImpl) createVerificationToken(ctx context.Context, user *entity.User) (string, error) {
+	tokenID, err := uuid.NewV4()
+	if err != nil {
+		return "", errors.New("failed to generate token")
+	}
+
+	verificationToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", errors.New("failed to generate token")
+	}
+
+	token := &entity.Token{
+		ID:        tokenID,
+		UserID:    user.ID,
+		Token:     verificationToken,
+		Type:      entity.TokenTypeVerification,
+		FamilyID:  tokenID,
+		ExpiresAt: time.Now().UTC().Add(s.verificationTTL),
+	}
+
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return "", fmt.Errorf("failed to save token: %w", err)
+	}
+
+	return verificationToken, nil
+}
+
+// mailVerification emails the user their verification code.
+func (s *UserServiceImpl) mailVerification(ctx context.Context, user *entity.User, verificationToken string) error {
+	body := fmt.Sprintf("Hi %s,\n\nUse this code to verify your email address: %s\nIt expires in %s.",
+		user.FirstName, verificationToken, s.verificationTTL)
+	if err := s.mailer.Send(ctx, user.Email, "Verify your email address", body); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+// SetupTwoFactor implements UserService. It starts an enrollment with a new
+// secret; the enrollment is not enforced until ConfirmTwoFactor succeeds.
+func (s *UserServiceImpl) SetupTwoFactor(ctx context.Context, userID uuid.UUID) (*TwoFactorSetup, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find user with ID %s: %w", userID, err)
+	}
+
+	existing, err := s.twoFactorRepo.FindByUser(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrTwoFactorNotFound) {
+		return nil, fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if existing != nil && existing.Enabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, errors.New("failed to generate secret")
+	}
+
+	if err := s.twoFactorRepo.Save(ctx, &entity.TwoFactor{UserID: userID, Secret: secret}); err != nil {
+		return nil, fmt.Errorf("failed to save two-factor enrollment: %w", err)
+	}
+
+	return &TwoFactorSetup{
+		Secret: secret,
+		URI:    auth.TOTPURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor implements UserService. It enables two-factor
+// authentication and returns the recovery codes, which are only shown once.
+func (s *UserServiceImpl) ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	twoFactor, err := s.twoFactorRepo.FindByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTwoFactorNotFound) {
+			return nil, ErrTwoFactorNotEnabled
+		}
+		return nil, fmt.Errorf("failed to get two-factor enrollment: %w", err)
+	}
+	if twoFactor.Enabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	// Two-factor authentication is only enabled together with the recovery
+	// codes that let the user back in without their authenticator
+	var codes []string
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.checkTOTP(ctx, twoFactor, code); err != nil {
+			return err
+		}
+
+		confirmedAt := time.Now().UTC()
+		twoFactor.ConfirmedAt = &confirmedAt
+		if err := s.twoFactorRepo.Save(ctx, twoFactor); err != nil {
+			return fmt.Errorf("failed to enable two-factor authentication: %w", err)
+		}
+
+		codes, err = s.replaceRecoveryCodes(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("Enabled two-factor authentication", "user_id", userID)
+	return codes, nil
+}
+
+// DisableTwoFactor implements UserService.
+func (s *UserServiceImpl) DisableTwoFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	twoFactor, err := s.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkSecondFactor(ctx, twoFactor, code); err != nil {
+		return err
+	}
+
+	if err := s.twoFactorRepo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("Disabled two-factor authentication", "user_id", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes implements UserService. Earlier recovery codes stop working.
+func (s *UserServiceImpl) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	twoFactor, err := s.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSecondFactor(ctx, twoFactor, code); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// enabledTwoFactor returns the user's confirmed two-factor enrollment.
+func (s *UserServiceImpl) enabledTwoFactor(ctx context.Context, userID uuid.UUID) (*entity.TwoFactor, error) {
+	twoFactor, err := s.twoFactorRepo.FindByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTwoFactorNotFound) {
+			return nil, ErrTwoFactorNotEnabled
+		}
+		return nil, fmt.Errorf("failed to get two-factor enrollment: %w", err)
+	}
+	if !twoFactor.Enabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	return twoFactor, nil
+}
+
+// checkSecondFactor accepts either an authenticator code or an unused recovery code.
+func (s *UserServiceImpl) checkSecondFactor(ctx context.Context, twoFactor *entity.TwoFactor, code string) error {
+	code = strings.TrimSpace(code)
+	if err := s.checkTOTP(ctx, twoFactor, code); err == nil || !errors.Is(err, ErrInvalidTwoFactorCode) {
+		return err
+	}
+
+	used, err := s.twoFactorRepo.UseRecoveryCode(ctx, twoFactor.UserID, normalizeRecoveryCode(code))
+	if err != nil {
+		return fmt.Errorf("failed to check recovery code: %w", err)
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+
+	logger.FromContext(ctx).Info("User signed in with a recovery code", "user_id", twoFactor.UserID)
+	return nil
+}
+
+// checkTOTP validates an authenticator code and rejects codes that were already used.
+func (s *UserServiceImpl) checkTOTP(ctx context.Context, twoFactor *entity.TwoFactor, code string) error {
+	step, ok := auth.ValidateTOTP(twoFactor.Secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	marked, err := s.twoFactorRepo.MarkStepUsed(ctx, twoFactor.UserID, step)
+	if err != nil {
+		return fmt.Errorf("failed to record two-factor code: %w", err)
+	}
+	if !marked {
+		return ErrInvalidTwoFactorCode
+	}
+
+	twoFactor.LastUsedStep = step
+	return nil
+}
+
+// replaceRecoveryCodes generates and stores a fresh set of recovery codes.
+func (s *UserServiceImpl) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, errors.New("failed to generate recovery codes")
+		}
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+		codes[i] = code[:4] + "-" + code[4:]
+	}
+
+	hashed := make([]string, len(codes))
+	for i, code := range codes {
+		hashed[i] = normalizeRecoveryCode(code)
+	}
+
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashed); err != nil {
+		return nil, fmt.Errorf("failed to save recovery codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+// normalizeRecoveryCode makes recovery codes case- and dash-insensitive.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
+
+// issueChallenge stores a short-lived token that proves the password step of a login succeeded.
+func (s *UserServiceImpl) issueChallenge(ctx context.Context, user *entity.User, client ClientInfo) (*TwoFactorChallenge, error) {
+	tokenID, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+
+	challengeToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+
+	token := &entity.Token{
+		ID:        tokenID,
+		UserID:    user.ID,
+		Token:     challengeToken,
+		Type:      entity.TokenTypeChallenge,
+		FamilyID:  tokenID,
+		ExpiresAt: time.Now().UTC().Add(s.challengeTTL),
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
+	}
+
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return nil, errors.New("failed to save token")
+	}
+
+	return &TwoFactorChallenge{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+		ExpiresAt:         token.ExpiresAt,
+	}, nil
+}
+
+// issueTokens signs an access token and stores a new refresh token in the given family.
+func (s *UserServiceImpl) issueTokens(ctx context.Context, user *entity.User, familyID uuid.UUID, client ClientInfo) (*LoginResult, error) {
+	accessToken, accessExpiresAt, err := s.tokenManager.Issue(user.ID, familyID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to sign access token", "error", err)
+		return nil, errors.New("failed to generate token")
+	}
+
+	tokenID, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+
+	refreshToken, err := utils.GenerateRandomToken(32)
 	if err != nil {
 		return nil, errors.New("failed to generate token")
 	}
 
 	// Create token entity
 	token := &entity.Token{
-		ID:        newToken,                       // Token ID
-		UserID:    user.ID,                        // Associate token with the user's ID
-		Token:     newToken.String(),              // The actual token string
-		ExpiresAt: time.Now().Add(24 * time.Hour), // Set token expiration (1 day in this example)
+		ID:        tokenID,                                 // Token ID
+		UserID:    user.ID,                                 // Associate token with the user's ID
+		Token:     refreshToken,                            // The actual token string
+		Type:      entity.TokenTypeRefresh,                 // Only refresh tokens are persisted
+		FamilyID:  familyID,                                // Chain of rotated tokens from one login
+		ExpiresAt: time.Now().UTC().Add(s.refreshTokenTTL), // Set token expiration
+		UserAgent: client.UserAgent,                        // Device the session is used from
+		IPAddress: client.IPAddress,
 	}
 
 	// Store the token in the database
-	if err := s.tokenRepo.Create(token); err != nil {
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
 		return nil, errors.New("failed to save token")
 	}
 
-	// Attach the token to the user entity
-
-	// Return the user and the token
-	return user, nil
+	// Return the tokens together with the user
+	return &LoginResult{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        accessExpiresAt,
+		ExpiresIn:        int64(s.tokenManager.TTL().Seconds()),
+		RefreshToken:     token.Token,
+		RefreshExpiresAt: token.ExpiresAt,
+		User:             user,
+	}, nil
 }
 
-// update user
-func (s *UserServiceImpl) UpdateUser(user *entity.User) error {
-	// Check if the user exists by their ID
-	_, err := s.repo.FindByID(user.ID)
+// update user. A new email address must be verified like the first one, so
+// changing it deactivates the account and sends a fresh verification code.
+func (s *UserServiceImpl) UpdateUser(ctx context.Context, user *entity.User) error {
+	var verificationToken string
+	var sessions []*entity.Session
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Check if the user exists by their ID
+		stored, err := s.repo.FindByID(ctx, user.ID)
+		if err != nil {
+			// If the user does not exist, return the error
+			return fmt.Errorf("could not find user with ID %s: %w", user.ID, err)
+		}
+
+		emailChanged := !strings.EqualFold(stored.Email, user.Email)
+		if emailChanged {
+			user.EmailVerifiedAt = nil
+			user.IsActive = false
+		}
+
+		// Call the repository to update the user
+		if err := s.repo.Update(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user with ID %s: %w", user.ID, err)
+		}
+
+		if emailChanged {
+			// Codes sent to the old address must not verify the new one
+			if err := s.tokenRepo.RevokeByType(ctx, user.ID, entity.TokenTypeVerification); err != nil {
+				return fmt.Errorf("failed to revoke verification codes for user %s: %w", user.ID, err)
+			}
+
+			// The account is unverified until the new address is confirmed,
+			// so its sessions end as a new sign-in would be refused
+			sessions, err = s.tokenRepo.ListSessions(ctx, user.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get sessions for user %s: %w", user.ID, err)
+			}
+			if err := s.tokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+				return fmt.Errorf("failed to revoke tokens for user %s: %w", user.ID, err)
+			}
+
+			verificationToken, err = s.createVerificationToken(ctx, user)
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		// If the user does not exist, return the error
-		return fmt.Errorf("could not find user with ID %s", user.ID)
+		return err
+	}
+
+	for _, session := range sessions {
+		s.revocations.MarkRevoked(session.ID)
 	}
 
-	// Call the repository to update the user
-	if err := s.repo.Update(user); err != nil {
-		return fmt.Errorf("failed to update user with ID %s: %v", user.ID, err)
+	if verificationToken != "" {
+		logger.FromContext(ctx).Info("Email changed, verification required", "user_id", user.ID)
+
+		// The change is saved, so a delivery failure is not fatal; the user can ask for a resend
+		if err := s.mailVerification(ctx, user, verificationToken); err != nil {
+			logger.FromContext(ctx).Error("Failed to send verification email", "user_id", user.ID, "error", err)
+		}
 	}
 
 	return nil
 }
 
 // delete user
-func (s *UserServiceImpl) DeleteUser(userID uuid.UUID) error {
-	// Check if the user exists by their ID
-	_, err := s.repo.FindByID(userID)
+func (s *UserServiceImpl) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	// The user's tokens are revoked in the same transaction that removes
+	// them, so no session outlives the account
+	var sessions []*entity.Session
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		// Check if the user exists by their ID
+		if _, err := s.repo.FindByID(ctx, userID); err != nil {
+			logger.FromContext(ctx).Error("Could not find user", "user_id", userID, "error", err)
+			return fmt.Errorf("could not find user with ID %s: %w", userID, err)
+		}
+
+		var err error
+		sessions, err = s.tokenRepo.ListSessions(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get sessions for user %s: %w", userID, err)
+		}
+
+		if err := s.tokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+			return fmt.Errorf("failed to revoke tokens for user %s: %w", userID, err)
+		}
+
+		// Call the repository to delete the user
+		if err := s.repo.Delete(ctx, userID); err != nil {
+			logger.FromContext(ctx).Error("Failed to delete user", "user_id", userID, "error", err)
+			return fmt.Errorf("failed to delete user with ID %s: %w", userID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		s.revocations.MarkRevoked(session.ID)
+	}
+
+	logger.FromContext(ctx).Info("Successfully deleted user", "user_id", userID)
+	return nil
+}
+
+// ListRoles implements UserService.
+func (s *UserServiceImpl) ListRoles(ctx context.Context, userID uuid.UUID) ([]*entity.Role, error) {
+	roles, err := s.roleRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles for user %s: %w", userID, err)
+	}
+
+	return roles, nil
+}
+
+// AssignRole implements UserService.
+func (s *UserServiceImpl) AssignRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	if _, err := s.repo.FindByID(ctx, userID); err != nil {
+		return fmt.Errorf("could not find user with ID %s: %w", userID, err)
+	}
+
+	if err := s.roleRepo.AssignToUser(ctx, userID, roleName); err != nil {
+		return fmt.Errorf("failed to assign role to user %s: %w", userID, err)
+	}
+
+	return nil
+}
+
+// RemoveRole implements UserService.
+func (s *UserServiceImpl) RemoveRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	if err := s.roleRepo.RemoveFromUser(ctx, userID, roleName); err != nil {
+		return fmt.Errorf("failed to remove role from user %s: %w", userID, err)
+	}
+
+	return nil
+}
+
+// UnlockUser implements UserService. It clears a sign-in lockout on the user's account.
+func (s *UserServiceImpl) UnlockUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	user, err := s.repo.FindByID(ctx, userID)
 	if err != nil {
-		// If the user does not exist, return the error
-		log.Printf("Could not find user with ID %s: %v", userID, err)
-		return fmt.Errorf("could not find user with ID %s", userID)
+		return fmt.Errorf("could not find user with ID %s: %w", userID, err)
 	}
 
-	// Call the repository to delete the user
-	if err := s.repo.Delete(userID); err != nil {
-		log.Printf("Failed to delete user with ID %s: %v", userID, err)
-		return fmt.Errorf("failed to delete user with ID %s: %v", userID, err)
+	if err := s.limiter.Unlock(ctx, user.Email, user.ID, actorID); err != nil {
+		return err
 	}
 
-	log.Printf("Successfully deleted user with ID %s", userID)
+	logger.FromContext(ctx).Info("User unlocked account", "actor_id", actorID, "user_id", userID)
 	return nil
 }
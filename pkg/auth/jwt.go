@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned when an access token cannot be verified
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Claims are the claims carried by an access token.
+// SessionID identifies the refresh token family the token was issued from.
+type Claims struct {
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// UserID returns the user the token was issued to.
+func (c *Claims) UserID() (uuid.UUID, error) {
+	return uuid.FromString(c.Subject)
+}
+
+// Session returns the session the token belongs to.
+func (c *Claims) Session() (uuid.UUID, error) {
+	return uuid.FromString(c.SessionID)
+}
+
+// signingKey is one entry of the key set
+type signingKey struct {
+	private crypto.PrivateKey // nil for verify-only keys
+	public  crypto.PublicKey
+}
+
+// TokenManager issues and verifies signed JWT access tokens.
+type TokenManager struct {
+	method    jwt.SigningMethod
+	activeKID string
+	keys      map[string]*signingKey
+	issuer    string
+	ttl       time.Duration
+}
+
+// NewTokenManager builds a TokenManager from the configured key set.
+func NewTokenManager(cfg *config.AuthConfig) (*TokenManager, error) {
+	method := jwt.GetSigningMethod(cfg.Algorithm)
+	switch method {
+	case jwt.SigningMethodHS256, jwt.SigningMethodRS256, jwt.SigningMethodEdDSA:
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", cfg.Algorithm)
+	}
+
+	manager := &TokenManager{
+		method:    method,
+		activeKID: cfg.ActiveKeyID,
+		keys:      map[string]*signingKey{},
+		issuer:    cfg.Issuer,
+		ttl:       cfg.AccessTokenTTL,
+	}
+
+	for kid, value := range cfg.Keys {
+		key, err := loadKey(method, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWT key %q: %v", kid, err)
+		}
+		manager.keys[kid] = key
+	}
+
+	// Without configured keys fall back to a random HMAC secret so local setups work;
+	// tokens will not survive a restart.
+	if len(manager.keys) == 0 {
+		if method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("JWT_KEYS is required for %s", cfg.Algorithm)
+		}
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		slog.Warn("JWT_KEYS not set, using an ephemeral signing key")
+		manager.activeKID = "ephemeral"
+		manager.keys[manager.activeKID] = &signingKey{private: secret, public: secret}
+	}
+
+	active, ok := manager.keys[manager.activeKID]
+	if !ok {
+		return nil, fmt.Errorf("active JWT key %q is not in the key set", manager.activeKID)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("active JWT key %q has no private key", manager.activeKID)
+	}
+
+	return manager, nil
+}
+
+// loadKey parses an HMAC secret or reads a PEM key file for the algorithm.
+func loadKey(method jwt.SigningMethod, value string) (*signingKey, error) {
+	if method == jwt.SigningMethodHS256 {
+		if value == "" {
+			return nil, errors.New("empty HMAC secret")
+		}
+		return &signingKey{private: []byte(value), public: []byte(value)}, nil
+	}
+
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed any
+	switch block.Type {
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if method != jwt.SigningMethodRS256 {
+			return nil, errors.New("RSA key does not match algorithm")
+		}
+		return &signingKey{private: k, public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		if method != jwt.SigningMethodRS256 {
+			return nil, errors.New("RSA key does not match algorithm")
+		}
+		return &signingKey{public: k}, nil
+	case ed25519.PrivateKey:
+		if method != jwt.SigningMethodEdDSA {
+			return nil, errors.New("Ed25519 key does not match algorithm")
+		}
+		return &signingKey{private: k, public: k.Public()}, nil
+	case ed25519.PublicKey:
+		if method != jwt.SigningMethodEdDSA {
+			return nil, errors.New("Ed25519 key does not match algorithm")
+		}
+		return &signingKey{public: k}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+}
+
+// Issue signs a new access token for the user's session.
+func (m *TokenManager) Issue(userID, sessionID uuid.UUID) (string, time.Time, error) {
+	tokenID, err := uuid.NewV4()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(m.ttl)
+
+	claims := &Claims{
+		SessionID: sessionID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID.String(),
+			Subject:   userID.String(),
+			Issuer:    m.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(m.method, claims)
+	token.Header["kid"] = m.activeKID
+
+	signed, err := token.SignedString(m.keys[m.activeKID].private)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+// Verify checks an access token's signature and registered claims.
+func (m *TokenManager) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := m.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		return key.public, nil
+	},
+		jwt.WithValidMethods([]string{m.method.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return claims, nil
+}
+
+// TTL returns how long issued access tokens stay valid.
+func (m *TokenManager) TTL() time.Duration {
+	return m.ttl
+}
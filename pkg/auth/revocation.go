@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"github.com/gofrs/uuid"
+)
+
+// RevocationList tells whether the session behind an access token has been
+// signed out. Active sessions are re-checked against the database at most
+// once per recheck interval; revocations made through this instance apply
+// immediately.
+type RevocationList struct {
+	tokenRepo repository.TokenRepository
+	recheck   time.Duration
+	retain    time.Duration
+
+	mu       sync.Mutex
+	active   map[uuid.UUID]time.Time // session -> when it was last confirmed active
+	revoked  map[uuid.UUID]time.Time // session -> when it was revoked
+	prunedAt time.Time
+}
+
+// NewRevocationList creates a RevocationList. Revoked sessions are remembered
+// for retain, which should be at least the access token lifetime.
+func NewRevocationList(tokenRepo repository.TokenRepository, recheck, retain time.Duration) *RevocationList {
+	return &RevocationList{
+		tokenRepo: tokenRepo,
+		recheck:   recheck,
+		retain:    retain,
+		active:    map[uuid.UUID]time.Time{},
+		revoked:   map[uuid.UUID]time.Time{},
+	}
+}
+
+// IsRevoked reports whether the session has been signed out.
+func (l *RevocationList) IsRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	if _, ok := l.revoked[sessionID]; ok {
+		l.mu.Unlock()
+		return true, nil
+	}
+	if checkedAt, ok := l.active[sessionID]; ok && now.Sub(checkedAt) < l.recheck {
+		l.mu.Unlock()
+		return false, nil
+	}
+	l.mu.Unlock()
+
+	revoked, err := l.tokenRepo.IsFamilyRevoked(ctx, sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	if revoked {
+		l.MarkRevoked(sessionID)
+	} else {
+		l.mu.Lock()
+		l.active[sessionID] = now
+		// Most sessions are never signed out, so the active entries are
+		// swept here too, once per recheck interval
+		if now.Sub(l.prunedAt) >= l.recheck {
+			l.prune(now)
+		}
+		l.mu.Unlock()
+	}
+
+	return revoked, nil
+}
+
+// MarkRevoked records sessions revoked by this instance so they are rejected
+// without waiting for the next database check.
+func (l *RevocationList) MarkRevoked(sessionIDs ...uuid.UUID) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, sessionID := range sessionIDs {
+		delete(l.active, sessionID)
+		l.revoked[sessionID] = now
+	}
+
+	l.prune(now)
+}
+
+// prune forgets entries that can no longer matter. Callers must hold l.mu.
+func (l *RevocationList) prune(now time.Time) {
+	for sessionID, revokedAt := range l.revoked {
+		if now.Sub(revokedAt) > l.retain {
+			delete(l.revoked, sessionID)
+		}
+	}
+	for sessionID, checkedAt := range l.active {
+		if now.Sub(checkedAt) > l.recheck {
+			delete(l.active, sessionID)
+		}
+	}
+	l.prunedAt = now
+}
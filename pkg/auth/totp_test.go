@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 seed of RFC 6238 Appendix B, "12345678901234567890", in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The SHA-1 test vectors of RFC 6238 Appendix B, cut to the last six digits
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestTOTPCode(t *testing.T) {
+	key := []byte("12345678901234567890")
+	for _, tt := range rfc6238Vectors {
+		if got := totpCode(key, tt.unix/totpPeriod); got != tt.code {
+			t.Errorf("totpCode at %d = %s, want %s", tt.unix, got, tt.code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	for _, tt := range rfc6238Vectors {
+		now := time.Unix(tt.unix, 0)
+		step, ok := ValidateTOTP(rfc6238Secret, tt.code, now)
+		if !ok || step != tt.unix/totpPeriod {
+			t.Errorf("ValidateTOTP(%s) at %d = %d, %v; want %d, true", tt.code, tt.unix, step, ok, tt.unix/totpPeriod)
+		}
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	issued := time.Unix(1111111111, 0)
+	code := "050471"
+	step := issued.Unix() / totpPeriod
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		valid  bool
+	}{
+		{"same period", 0, true},
+		{"one period early", -totpPeriod * time.Second, true},
+		{"one period late", totpPeriod * time.Second, true},
+		{"two periods early", -2 * totpPeriod * time.Second, false},
+		{"two periods late", 2 * totpPeriod * time.Second, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ValidateTOTP(rfc6238Secret, code, issued.Add(tt.offset))
+			if ok != tt.valid {
+				t.Fatalf("ValidateTOTP ok = %v, want %v", ok, tt.valid)
+			}
+			// A code accepted late still reports the step it was issued for
+			if ok && got != step {
+				t.Errorf("ValidateTOTP step = %d, want %d", got, step)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPReplay(t *testing.T) {
+	// Callers reject replays by refusing a step they have already used, so
+	// the same code must map to the same step every time it is accepted
+	issued := time.Unix(1234567890, 0)
+	first, ok := ValidateTOTP(rfc6238Secret, "005924", issued)
+	if !ok {
+		t.Fatal("code was not accepted when issued")
+	}
+
+	replayed, ok := ValidateTOTP(rfc6238Secret, "005924", issued.Add(totpPeriod*time.Second))
+	if !ok {
+		t.Fatal("code was not accepted within the skew")
+	}
+	if replayed != first {
+		t.Errorf("replayed code reported step %d, want %d", replayed, first)
+	}
+
+	// The next period's code has a later step, so it is not mistaken for a replay
+	next := totpCode([]byte("12345678901234567890"), first+1)
+	later, ok := ValidateTOTP(rfc6238Secret, next, issued.Add(totpPeriod*time.Second))
+	if !ok || later != first+1 {
+		t.Errorf("next code = %d, %v; want %d, true", later, ok, first+1)
+	}
+}
+
+func TestValidateTOTPRejectsMalformedInput(t *testing.T) {
+	now := time.Unix(59, 0)
+	tests := []struct {
+		name, secret, code string
+	}{
+		{"wrong code", rfc6238Secret, "123456"},
+		{"short code", rfc6238Secret, "28708"},
+		{"long code", rfc6238Secret, "2870820"},
+		{"eight digit code", rfc6238Secret, "94287082"},
+		{"invalid secret", "not base32!", "287082"},
+		{"empty code", rfc6238Secret, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ValidateTOTP(tt.secret, tt.code, now); ok {
+				t.Errorf("ValidateTOTP(%q, %q) accepted", tt.secret, tt.code)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPAcceptsLowerCaseSecret(t *testing.T) {
+	if _, ok := ValidateTOTP("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "287082", time.Unix(59, 0)); !ok {
+		t.Error("lower case secret was rejected")
+	}
+}
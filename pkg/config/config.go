@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // DBConfig holds the database configuration.
@@ -35,3 +38,213 @@ func (c *DBConfig) ConnectionString() string {
 	)
 
 }
+
+// AuthConfig holds the token signing configuration.
+type AuthConfig struct {
+	// Algorithm is the JWT signing algorithm: HS256, RS256 or EdDSA.
+	Algorithm string
+	// ActiveKeyID is the key ID used to sign new access tokens.
+	ActiveKeyID string
+	// Keys maps key IDs to an HMAC secret (HS256) or a PEM file path (RS256, EdDSA).
+	// Keys other than the active one are only used to verify tokens.
+	Keys            map[string]string
+	Issuer          string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// TokenHashKey is the server secret used to hash tokens stored in the database.
+	TokenHashKey     string
+	PasswordResetTTL time.Duration
+	// PasswordResetInterval is the minimum time between password reset emails to one user.
+	PasswordResetInterval time.Duration
+	VerificationTTL       time.Duration
+	// VerificationResendInterval is the minimum time between verification emails to one user.
+	VerificationResendInterval time.Duration
+	// TwoFactorChallengeTTL is how long a user has to enter their second factor after the password.
+	TwoFactorChallengeTTL time.Duration
+}
+
+// LoadAuthConfig loads the token signing configuration from environment variables.
+// JWT_KEYS is a comma-separated list of kid=value pairs.
+func LoadAuthConfig() *AuthConfig {
+	keys := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv("JWT_KEYS"), ",") {
+		kid, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if ok && kid != "" {
+			keys[kid] = value
+		}
+	}
+
+	return &AuthConfig{
+		Algorithm:        getEnv("JWT_ALGORITHM", "HS256"),
+		ActiveKeyID:      os.Getenv("JWT_ACTIVE_KEY_ID"),
+		Keys:             keys,
+		TokenHashKey:     os.Getenv("TOKEN_HASH_KEY"),
+		PasswordResetTTL: getDurationEnv("PASSWORD_RESET_TTL", 30*time.Minute),
+		VerificationTTL:  getDurationEnv("VERIFICATION_TTL", 24*time.Hour),
+
+		PasswordResetInterval:      getDurationEnv("PASSWORD_RESET_INTERVAL", time.Minute),
+		VerificationResendInterval: getDurationEnv("VERIFICATION_RESEND_INTERVAL", time.Minute),
+		TwoFactorChallengeTTL:      getDurationEnv("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute),
+		Issuer:                     getEnv("JWT_ISSUER", "event-management-system"),
+		AccessTokenTTL:             getDurationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:            getDurationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+	}
+}
+
+// getEnv returns the environment variable or a fallback when it is unset.
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getDurationEnv parses a duration such as "15m" from the environment, falling back when unset or invalid.
+func getDurationEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getIntEnv parses an integer from the environment, falling back when unset or invalid.
+func getIntEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// NotifierConfig selects how user notifications are delivered.
+type NotifierConfig struct {
+	// Driver is "log", "file" or "email".
+	Driver string
+	// FilePath is where the file driver writes notifications.
+	FilePath string
+}
+
+// LoadNotifierConfig loads the notifier configuration from environment variables.
+func LoadNotifierConfig() *NotifierConfig {
+	return &NotifierConfig{
+		Driver:   getEnv("NOTIFIER", "log"),
+		FilePath: getEnv("NOTIFIER_FILE", "notifications.log"),
+	}
+}
+
+// MailerConfig selects how email is sent.
+type MailerConfig struct {
+	// Driver is "smtp", "file" or "memory".
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	// FilePath is where the file driver writes messages.
+	FilePath string
+}
+
+// LoadMailerConfig loads the mailer configuration from environment variables.
+func LoadMailerConfig() *MailerConfig {
+	return &MailerConfig{
+		Driver:       getEnv("MAILER", "file"),
+		From:         getEnv("MAIL_FROM", "no-reply@localhost"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		FilePath:     getEnv("MAILER_FILE", "mail.log"),
+	}
+}
+
+// LockoutConfig controls brute-force protection on sign-in.
+type LockoutConfig struct {
+	// Store is "postgres" or "memory". The memory store is per instance.
+	Store string
+	// AccountThreshold and IPThreshold are the failed attempts allowed before a lockout.
+	AccountThreshold int
+	IPThreshold      int
+	// BaseLockout doubles with every failure past the threshold, up to MaxLockout.
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+	// FailureWindow is how long without failures before the count starts over.
+	FailureWindow time.Duration
+}
+
+// LoadLockoutConfig loads the lockout configuration from environment variables.
+func LoadLockoutConfig() *LockoutConfig {
+	return &LockoutConfig{
+		Store:            getEnv("LOCKOUT_STORE", "postgres"),
+		AccountThreshold: getIntEnv("LOCKOUT_ACCOUNT_THRESHOLD", 5),
+		IPThreshold:      getIntEnv("LOCKOUT_IP_THRESHOLD", 20),
+		BaseLockout:      getDurationEnv("LOCKOUT_BASE_DURATION", time.Minute),
+		MaxLockout:       getDurationEnv("LOCKOUT_MAX_DURATION", time.Hour),
+		FailureWindow:    getDurationEnv("LOCKOUT_FAILURE_WINDOW", time.Hour),
+	}
+}
+
+// LogConfig controls the application logger.
+type LogConfig struct {
+	// Level is "debug", "info", "warn" or "error".
+	Level string
+	// Format is "json" or "text".
+	Format string
+	// RedactFields lists attribute key fragments whose values are never written.
+	RedactFields []string
+}
+
+// LoadLogConfig loads the logger configuration from environment variables.
+// LOG_REDACT_FIELDS is a comma-separated list.
+func LoadLogConfig() *LogConfig {
+	return &LogConfig{
+		Level:        getEnv("LOG_LEVEL", "info"),
+		Format:       getEnv("LOG_FORMAT", "json"),
+		RedactFields: strings.Split(getEnv("LOG_REDACT_FIELDS", "password,token,email,secret,code"), ","),
+	}
+}
+
+// ServerConfig holds HTTP server settings.
+type ServerConfig struct {
+	// RequestTimeout bounds how long a request may spend in handlers, services
+	// and database calls before its context is cancelled.
+	RequestTimeout time.Duration
+	// TrustedProxies lists the proxy IPs or CIDRs whose X-Forwarded-For header
+	// is believed when working out a client's IP. Empty trusts none, so the
+	// client IP is always the address of the connection.
+	TrustedProxies []string
+}
+
+// LoadServerConfig loads the HTTP server configuration from environment variables.
+// TRUSTED_PROXIES is a comma-separated list.
+func LoadServerConfig() *ServerConfig {
+	var trustedProxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+
+	return &ServerConfig{
+		RequestTimeout: getDurationEnv("REQUEST_TIMEOUT", 10*time.Second),
+		TrustedProxies: trustedProxies,
+	}
+}
+
+// PurgeConfig controls the job that permanently removes deleted records.
+type PurgeConfig struct {
+	// Retention is how long soft-deleted users and events, and revoked or
+	// expired tokens, are kept before they are removed for good
+	Retention time.Duration
+	// Interval is how often the job runs; zero disables it
+	Interval time.Duration
+}
+
+// LoadPurgeConfig loads the purge job configuration from environment variables.
+func LoadPurgeConfig() *PurgeConfig {
+	return &PurgeConfig{
+		Retention: getDurationEnv("PURGE_RETENTION", 30*24*time.Hour),
+		Interval:  getDurationEnv("PURGE_INTERVAL", 6*time.Hour),
+	}
+}
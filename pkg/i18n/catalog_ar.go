@@ -0,0 +1,96 @@
+package i18n
+
+// arabic is the Arabic catalog
+var arabic = map[string]string{
+	// Problem titles, by HTTP status
+	"status.400": "طلب غير صالح",
+	"status.401": "غير مصرح",
+	"status.403": "ممنوع",
+	"status.404": "غير موجود",
+	"status.409": "تعارض",
+	"status.422": "لا يمكن معالجة الطلب",
+	"status.429": "طلبات كثيرة جدًا",
+	"status.500": "خطأ داخلي في الخادم",
+	"status.504": "انتهت مهلة البوابة",
+
+	// Generic details, by error kind, for messages missing from the catalog
+	"detail.not_found":         "المورد المطلوب غير موجود",
+	"detail.conflict":          "يتعارض الطلب مع الحالة الحالية للمورد",
+	"detail.validation":        "يخالف الطلب قاعدة من قواعد العمل",
+	"detail.forbidden":         "غير مسموح لك بالقيام بذلك",
+	"detail.unauthorized":      "فشلت المصادقة",
+	"detail.too_many_requests": "طلبات كثيرة جدًا، حاول مرة أخرى لاحقًا",
+	"detail.bad_request":       "تعذّر فهم الطلب",
+
+	// Error messages, keyed by their English text
+	"invalid request body":                                    "نص الطلب غير صالح",
+	"invalid query parameters":                                "معاملات الاستعلام غير صالحة",
+	"invalid pagination cursor":                               "مؤشر الصفحات غير صالح",
+	"request has invalid fields":                              "يحتوي الطلب على حقول غير صالحة",
+	"invalid event ID":                                        "معرّف الفعالية غير صالح",
+	"invalid user ID":                                         "معرّف المستخدم غير صالح",
+	"invalid session ID":                                      "معرّف الجلسة غير صالح",
+	"user ID is required":                                     "معرّف المستخدم مطلوب",
+	"Request timed out":                                       "انتهت مهلة الطلب",
+	"An unexpected error occurred":                            "حدث خطأ غير متوقع",
+	"Authorization token required":                            "رمز التفويض مطلوب",
+	"Authorization format must be Bearer <token>":             "يجب أن يكون تنسيق التفويض Bearer <token>",
+	"Invalid or expired token":                                "الرمز غير صالح أو منتهي الصلاحية",
+	"Token has been revoked":                                  "تم إلغاء الرمز",
+	"Authentication required":                                 "المصادقة مطلوبة",
+	"You do not have permission to perform this action":       "ليس لديك صلاحية لتنفيذ هذا الإجراء",
+	"invalid email or password":                               "البريد الإلكتروني أو كلمة المرور غير صحيحة",
+	"email address has not been verified":                     "لم يتم التحقق من عنوان البريد الإلكتروني",
+	"too many failed sign-in attempts":                        "محاولات تسجيل دخول فاشلة كثيرة جدًا",
+	"invalid or expired refresh token":                        "رمز التحديث غير صالح أو منتهي الصلاحية",
+	"refresh token has already been used":                     "تم استخدام رمز التحديث من قبل",
+	"session not found":                                       "الجلسة غير موجودة",
+	"invalid or expired password reset token":                 "رمز إعادة تعيين كلمة المرور غير صالح أو منتهي الصلاحية",
+	"invalid or expired verification token":                   "رمز التحقق غير صالح أو منتهي الصلاحية",
+	"two-factor authentication is already enabled":            "المصادقة الثنائية مفعّلة بالفعل",
+	"two-factor authentication is not enabled":                "المصادقة الثنائية غير مفعّلة",
+	"two-factor authentication is not set up":                 "لم يتم إعداد المصادقة الثنائية",
+	"invalid two-factor code":                                 "رمز المصادقة الثنائية غير صحيح",
+	"invalid or expired login challenge":                      "تحدي تسجيل الدخول غير صالح أو منتهي الصلاحية",
+	"user not found":                                          "المستخدم غير موجود",
+	"username or email is already in use":                     "اسم المستخدم أو البريد الإلكتروني مستخدم بالفعل",
+	"no deleted user with this ID":                            "لا يوجد مستخدم محذوف بهذا المعرّف",
+	"event not found":                                         "الفعالية غير موجودة",
+	"no deleted event with this ID":                           "لا توجد فعالية محذوفة بهذا المعرّف",
+	"you are not allowed to modify this event":                "غير مسموح لك بتعديل هذه الفعالية",
+	"invalid event schedule":                                  "جدول الفعالية غير صالح",
+	"invalid event status transition":                         "لا يمكن نقل الفعالية إلى هذه الحالة",
+	"status must be changed through the transition endpoints": "يجب تغيير الحالة من خلال نقاط نهاية الانتقال",
+	"event status was changed by another request":             "تم تغيير حالة الفعالية بواسطة طلب آخر",
+	"capacity is below the confirmed registrations":           "السعة أقل من عدد التسجيلات المؤكدة",
+	"event is not open for registration":                      "التسجيل في هذه الفعالية غير متاح",
+	"user is already registered for this event":               "المستخدم مسجّل بالفعل في هذه الفعالية",
+	"registration not found":                                  "التسجيل غير موجود",
+	"user is not a co-organizer of this event":                "المستخدم ليس منظمًا مشاركًا لهذه الفعالية",
+	`role "{0}" not found`:                                    `الدور "{0}" غير موجود`,
+	`user does not have role "{0}"`:                           `ليس لدى المستخدم الدور "{0}"`,
+	`events cannot be created as "{0}"`:                       `لا يمكن إنشاء الفعاليات بالحالة "{0}"`,
+
+	// Field validation messages. {0} is the field name
+	"field.required":          "{0} مطلوب",
+	"field.invalid":           "{0} غير صالح",
+	"field.invalid_type":      "يجب أن يكون {0} {1}",
+	"field.invalid_email":     "يجب أن يكون {0} عنوان بريد إلكتروني صالحًا",
+	"field.too_short":         "يجب ألا يقل {0} عن {1} أحرف",
+	"field.too_long":          "يجب ألا يزيد {0} عن {1} حرفًا",
+	"field.too_small":         "يجب ألا يقل {0} عن {1}",
+	"field.too_large":         "يجب ألا يزيد {0} عن {1}",
+	"field.must_be_after":     "يجب أن يكون {0} بعد {1}",
+	"field.weak_password":     "يجب أن يتكون {0} من {1} إلى {2} حرفًا وأن يحتوي على حرف كبير وحرف صغير ورقم",
+	"field.out_of_range":      "يجب أن يكون {0} بين {1} و{2}",
+	"field.invalid_time_zone": "يجب أن يكون {0} منطقة زمنية من IANA مثل Africa/Mogadishu",
+	"field.invalid_choice":    "{0} ليس من القيم المسموح بها",
+	"field.invalid_uuid":      "{0} يجب أن يكون معرّفًا صالحًا",
+
+	// Values substituted into field messages
+	"param.number":  "رقمًا",
+	"param.boolean": "true أو false",
+	"param.string":  "نصًا",
+	"param.array":   "مصفوفة",
+	"param.object":  "كائنًا",
+}
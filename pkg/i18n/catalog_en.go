@@ -0,0 +1,96 @@
+package i18n
+
+// english is the English catalog. It is the fallback, so it holds every key.
+var english = map[string]string{
+	// Problem titles, by HTTP status
+	"status.400": "Bad Request",
+	"status.401": "Unauthorized",
+	"status.403": "Forbidden",
+	"status.404": "Not Found",
+	"status.409": "Conflict",
+	"status.422": "Unprocessable Entity",
+	"status.429": "Too Many Requests",
+	"status.500": "Internal Server Error",
+	"status.504": "Gateway Timeout",
+
+	// Generic details, by error kind, for messages missing from the catalog
+	"detail.not_found":         "The requested resource was not found",
+	"detail.conflict":          "The request conflicts with the current state of the resource",
+	"detail.validation":        "The request breaks a business rule",
+	"detail.forbidden":         "You are not allowed to do this",
+	"detail.unauthorized":      "Authentication failed",
+	"detail.too_many_requests": "Too many requests, try again later",
+	"detail.bad_request":       "The request could not be understood",
+
+	// Error messages, keyed by their English text
+	"invalid request body":                                    "invalid request body",
+	"invalid query parameters":                                "invalid query parameters",
+	"invalid pagination cursor":                               "invalid pagination cursor",
+	"request has invalid fields":                              "request has invalid fields",
+	"invalid event ID":                                        "invalid event ID",
+	"invalid user ID":                                         "invalid user ID",
+	"invalid session ID":                                      "invalid session ID",
+	"user ID is required":                                     "user ID is required",
+	"Request timed out":                                       "Request timed out",
+	"An unexpected error occurred":                            "An unexpected error occurred",
+	"Authorization token required":                            "Authorization token required",
+	"Authorization format must be Bearer <token>":             "Authorization format must be Bearer <token>",
+	"Invalid or expired token":                                "Invalid or expired token",
+	"Token has been revoked":                                  "Token has been revoked",
+	"Authentication required":                                 "Authentication required",
+	"You do not have permission to perform this action":       "You do not have permission to perform this action",
+	"invalid email or password":                               "invalid email or password",
+	"email address has not been verified":                     "email address has not been verified",
+	"too many failed sign-in attempts":                        "too many failed sign-in attempts",
+	"invalid or expired refresh token":                        "invalid or expired refresh token",
+	"refresh token has already been used":                     "refresh token has already been used",
+	"session not found":                                       "session not found",
+	"invalid or expired password reset token":                 "invalid or expired password reset token",
+	"invalid or expired verification token":                   "invalid or expired verification token",
+	"two-factor authentication is already enabled":            "two-factor authentication is already enabled",
+	"two-factor authentication is not enabled":                "two-factor authentication is not enabled",
+	"two-factor authentication is not set up":                 "two-factor authentication is not set up",
+	"invalid two-factor code":                                 "invalid two-factor code",
+	"invalid or expired login challenge":                      "invalid or expired login challenge",
+	"user not found":                                          "user not found",
+	"username or email is already in use":                     "username or email is already in use",
+	"no deleted user with this ID":                            "no deleted user with this ID",
+	"event not found":                                         "event not found",
+	"no deleted event with this ID":                           "no deleted event with this ID",
+	"you are not allowed to modify this event":                "you are not allowed to modify this event",
+	"invalid event schedule":                                  "invalid event schedule",
+	"invalid event status transition":                         "invalid event status transition",
+	"status must be changed through the transition endpoints": "status must be changed through the transition endpoints",
+	"event status was changed by another request":             "event status was changed by another request",
+	"capacity is below the confirmed registrations":           "capacity is below the confirmed registrations",
+	"event is not open for registration":                      "event is not open for registration",
+	"user is already registered for this event":               "user is already registered for this event",
+	"registration not found":                                  "registration not found",
+	"user is not a co-organizer of this event":                "user is not a co-organizer of this event",
+	`role "{0}" not found`:                                    `role "{0}" not found`,
+	`user does not have role "{0}"`:                           `user does not have role "{0}"`,
+	`events cannot be created as "{0}"`:                       `events cannot be created as "{0}"`,
+
+	// Field validation messages. {0} is the field name
+	"field.required":          "{0} is required",
+	"field.invalid":           "{0} is invalid",
+	"field.invalid_type":      "{0} must be {1}",
+	"field.invalid_email":     "{0} must be a valid email address",
+	"field.too_short":         "{0} must be at least {1} characters",
+	"field.too_long":          "{0} must be at most {1} characters",
+	"field.too_small":         "{0} must be at least {1}",
+	"field.too_large":         "{0} must be at most {1}",
+	"field.must_be_after":     "{0} must be after {1}",
+	"field.weak_password":     "{0} must be {1} to {2} characters and contain an upper case letter, a lower case letter and a digit",
+	"field.out_of_range":      "{0} must be between {1} and {2}",
+	"field.invalid_time_zone": "{0} must be an IANA time zone such as Africa/Mogadishu",
+	"field.invalid_choice":    "{0} is not one of the allowed values",
+	"field.invalid_uuid":      "{0} must be a valid ID",
+
+	// Values substituted into field messages
+	"param.number":  "a number",
+	"param.boolean": "true or false",
+	"param.string":  "a string",
+	"param.array":   "an array",
+	"param.object":  "an object",
+}
@@ -0,0 +1,96 @@
+package i18n
+
+// somali is the Somali catalog
+var somali = map[string]string{
+	// Problem titles, by HTTP status
+	"status.400": "Codsi khaldan",
+	"status.401": "Aqoonsi la'aan",
+	"status.403": "Waa la mamnuucay",
+	"status.404": "Lama helin",
+	"status.409": "Isku dhac",
+	"status.422": "Codsiga lama farsamayn karo",
+	"status.429": "Codsiyo aad u badan",
+	"status.500": "Cilad gudaha adeegaha ah",
+	"status.504": "Wakhtiga albaabka wuu dhammaaday",
+
+	// Generic details, by error kind, for messages missing from the catalog
+	"detail.not_found":         "Kheyraadka la codsaday lama helin",
+	"detail.conflict":          "Codsigu wuu khilaafsan yahay xaaladda hadda ee kheyraadka",
+	"detail.validation":        "Codsigu wuxuu jebinayaa xeer ganacsi",
+	"detail.forbidden":         "Laguuma oggola inaad tan samayso",
+	"detail.unauthorized":      "Xaqiijintu way fashilantay",
+	"detail.too_many_requests": "Codsiyo aad u badan, isku day mar dambe",
+	"detail.bad_request":       "Codsiga lama fahmi karo",
+
+	// Error messages, keyed by their English text
+	"invalid request body":                                    "Qoraalka codsigu waa khalad",
+	"invalid query parameters":                                "Qiimayaasha weydiinta waa khalad",
+	"invalid pagination cursor":                               "Calaamadda bogga waa khalad",
+	"request has invalid fields":                              "Codsigu wuxuu leeyahay goobo khaldan",
+	"invalid event ID":                                        "Aqoonsiga dhacdadu waa khalad",
+	"invalid user ID":                                         "Aqoonsiga isticmaalaha waa khalad",
+	"invalid session ID":                                      "Aqoonsiga fadhigu waa khalad",
+	"user ID is required":                                     "Aqoonsiga isticmaalaha waa loo baahan yahay",
+	"Request timed out":                                       "Wakhtiga codsiga wuu dhammaaday",
+	"An unexpected error occurred":                            "Cilad lama filaan ah ayaa dhacday",
+	"Authorization token required":                            "Calaamadda oggolaanshaha waa loo baahan yahay",
+	"Authorization format must be Bearer <token>":             "Qaabka oggolaanshaha waa inuu noqdaa Bearer <token>",
+	"Invalid or expired token":                                "Calaamaddu waa khalad ama way dhacday",
+	"Token has been revoked":                                  "Calaamadda waa la buriyay",
+	"Authentication required":                                 "Aqoonsi ayaa loo baahan yahay",
+	"You do not have permission to perform this action":       "Ma haysatid oggolaansho aad ku samayso falkan",
+	"invalid email or password":                               "Iimaylka ama furaha sirta ah waa khalad",
+	"email address has not been verified":                     "Cinwaanka iimaylka weli lama xaqiijin",
+	"too many failed sign-in attempts":                        "Isku dayo gelitaan oo fashilmay ayaa aad u badan",
+	"invalid or expired refresh token":                        "Calaamadda cusboonaysiintu waa khalad ama way dhacday",
+	"refresh token has already been used":                     "Calaamadda cusboonaysiinta horay ayaa loo isticmaalay",
+	"session not found":                                       "Fadhiga lama helin",
+	"invalid or expired password reset token":                 "Koodhka dib u dejinta furaha sirta ah waa khalad ama wuu dhacay",
+	"invalid or expired verification token":                   "Koodhka xaqiijintu waa khalad ama wuu dhacay",
+	"two-factor authentication is already enabled":            "Xaqiijinta laba-tallaabo horay ayaa loo daaray",
+	"two-factor authentication is not enabled":                "Xaqiijinta laba-tallaabo lama daarin",
+	"two-factor authentication is not set up":                 "Xaqiijinta laba-tallaabo lama dejin",
+	"invalid two-factor code":                                 "Koodhka xaqiijinta laba-tallaabo waa khalad",
+	"invalid or expired login challenge":                      "Caqabadda gelitaanku waa khalad ama way dhacday",
+	"user not found":                                          "Isticmaalaha lama helin",
+	"username or email is already in use":                     "Magaca isticmaalaha ama iimaylka horay ayaa loo isticmaalay",
+	"no deleted user with this ID":                            "Ma jiro isticmaale la tirtiray oo wata aqoonsigan",
+	"event not found":                                         "Dhacdada lama helin",
+	"no deleted event with this ID":                           "Ma jirto dhacdo la tirtiray oo wata aqoonsigan",
+	"you are not allowed to modify this event":                "Laguuma oggola inaad wax ka beddesho dhacdadan",
+	"invalid event schedule":                                  "Jadwalka dhacdadu waa khalad",
+	"invalid event status transition":                         "Dhacdada looma wareejin karo xaaladdan",
+	"status must be changed through the transition endpoints": "Xaaladda waa in lagu beddelaa dhibcaha wareejinta",
+	"event status was changed by another request":             "Xaaladda dhacdada waxaa beddelay codsi kale",
+	"capacity is below the confirmed registrations":           "Awoodda waa ka yar tahay diiwaangelinnada la xaqiijiyay",
+	"event is not open for registration":                      "Dhacdadan diiwaangelintu uma furna",
+	"user is already registered for this event":               "Isticmaaluhu horay ayuu ugu diiwaangashanaa dhacdadan",
+	"registration not found":                                  "Diiwaangelinta lama helin",
+	"user is not a co-organizer of this event":                "Isticmaaluhu ma aha wada-qabanqaabiyaha dhacdadan",
+	`role "{0}" not found`:                                    `Doorka "{0}" lama helin`,
+	`user does not have role "{0}"`:                           `Isticmaaluhu ma laha doorka "{0}"`,
+	`events cannot be created as "{0}"`:                       `Dhacdooyinka looma abuuri karo sidii "{0}"`,
+
+	// Field validation messages. {0} is the field name
+	"field.required":          "{0} waa loo baahan yahay",
+	"field.invalid":           "{0} waa khalad",
+	"field.invalid_type":      "{0} waa inuu noqdaa {1}",
+	"field.invalid_email":     "{0} waa inuu noqdaa cinwaan iimayl oo sax ah",
+	"field.too_short":         "{0} waa inuu ahaadaa ugu yaraan {1} xaraf",
+	"field.too_long":          "{0} waa inuusan ka badnaan {1} xaraf",
+	"field.too_small":         "{0} waa inuu ahaadaa ugu yaraan {1}",
+	"field.too_large":         "{0} waa inuusan ka badnaan {1}",
+	"field.must_be_after":     "{0} waa inuu ka dambeeyaa {1}",
+	"field.weak_password":     "{0} waa inuu ahaadaa {1} ilaa {2} xaraf oo ay ku jiraan xaraf weyn, xaraf yar iyo tiro",
+	"field.out_of_range":      "{0} waa inuu u dhexeeyaa {1} iyo {2}",
+	"field.invalid_time_zone": "{0} waa inuu noqdaa aag wakhti IANA sida Africa/Mogadishu",
+	"field.invalid_choice":    "{0} kama mid aha qiimayaasha la oggol yahay",
+	"field.invalid_uuid":      "{0} waa inuu noqdaa aqoonsi sax ah",
+
+	// Values substituted into field messages
+	"param.number":  "tiro",
+	"param.boolean": "true ama false",
+	"param.string":  "qoraal",
+	"param.array":   "liis",
+	"param.object":  "shay",
+}
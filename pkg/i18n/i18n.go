@@ -0,0 +1,113 @@
+// Package i18n translates the messages the API sends to clients. The
+// catalogs are keyed by the English text of each message, so a message
+// without a translation can still be shown as is.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/ar"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/so"
+	ut "github.com/go-playground/universal-translator"
+)
+
+// catalogs holds the messages of every supported locale. English is the
+// fallback and must hold every key.
+var catalogs = []struct {
+	locale   locales.Translator
+	messages map[string]string
+}{
+	{en.New(), english},
+	{ar.New(), arabic},
+	{so.New(), somali},
+}
+
+// universal is the translator for every supported locale
+var universal = newUniversalTranslator()
+
+// newUniversalTranslator loads the catalogs. A malformed catalog is a
+// programming error, so it panics rather than starting with missing text.
+func newUniversalTranslator() *ut.UniversalTranslator {
+	fallback := catalogs[0].locale
+	supported := make([]locales.Translator, 0, len(catalogs))
+	for _, catalog := range catalogs {
+		supported = append(supported, catalog.locale)
+	}
+	uni := ut.New(fallback, supported...)
+
+	for _, catalog := range catalogs {
+		trans, _ := uni.GetTranslator(catalog.locale.Locale())
+		for key, text := range catalog.messages {
+			if err := trans.Add(key, text, false); err != nil {
+				panic(fmt.Sprintf("i18n: invalid %s message %q: %v", catalog.locale.Locale(), key, err))
+			}
+		}
+	}
+	return uni
+}
+
+// Fallback returns the English translator.
+func Fallback() ut.Translator {
+	return universal.GetFallback()
+}
+
+// Negotiate picks the translator for an Accept-Language header. Languages
+// are tried in order of preference, each by its full tag and then by its
+// base language, so "so-SO" is served in Somali. English is the fallback.
+func Negotiate(acceptLanguage string) ut.Translator {
+	type preference struct {
+		tag     string
+		quality float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		preferences = append(preferences, preference{tag: tag, quality: quality})
+	}
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].quality > preferences[j].quality })
+
+	candidates := make([]string, 0, 2*len(preferences))
+	for _, pref := range preferences {
+		tag := strings.ReplaceAll(pref.tag, "-", "_")
+		candidates = append(candidates, tag)
+		if base, _, found := strings.Cut(tag, "_"); found {
+			candidates = append(candidates, base)
+		}
+	}
+
+	trans, _ := universal.FindTranslator(candidates...)
+	return trans
+}
+
+// Translate renders the message key in trans, falling back to English when
+// trans has no translation for it. It reports false when neither has the key.
+func Translate(trans ut.Translator, key string, params ...string) (string, bool) {
+	if text, err := trans.T(key, params...); err == nil {
+		return text, true
+	}
+	if text, err := Fallback().T(key, params...); err == nil {
+		return text, true
+	}
+	return "", false
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/config"
+)
+
+// Redacted replaces the value of any sensitive attribute
+const Redacted = "[REDACTED]"
+
+// New builds a structured logger that writes to w. Attributes whose key
+// contains one of the redact fields (case-insensitive) have their value
+// replaced, so "password", "refresh_token" and "user_email" are all hidden
+// when the fields are password, token and email.
+func New(w io.Writer, cfg *config.LogConfig) *slog.Logger {
+	fields := make([]string, 0, len(cfg.RedactFields))
+	for _, field := range cfg.RedactFields {
+		if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	options := &slog.HandlerOptions{
+		Level: parseLevel(cfg.Level),
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Value.Kind() != slog.KindGroup && isSensitive(attr.Key, fields) {
+				return slog.String(attr.Key, Redacted)
+			}
+			return attr
+		},
+	}
+
+	if strings.EqualFold(cfg.Format, "text") {
+		return slog.New(slog.NewTextHandler(w, options))
+	}
+	return slog.New(slog.NewJSONHandler(w, options))
+}
+
+// Setup builds the logger and installs it as the default, so the slog
+// package functions and the standard log package both go through it.
+func Setup(w io.Writer, cfg *config.LogConfig) *slog.Logger {
+	logger := New(w, cfg)
+	slog.SetDefault(logger)
+	return logger
+}
+
+func isSensitive(key string, fields []string) bool {
+	key = strings.ToLower(key)
+	for _, field := range fields {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseLevel(level string) slog.Level {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return parsed
+}
@@ -1,23 +1,24 @@
 package middlewares
 
 import (
-	"log"
-	"net/http"
+	"fmt"
 	"strings"
-	"time"
 
-	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/auth"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware verifies the token and protects the routes by checking the token in the database.
-func AuthMiddleware(tokenRepo repository.TokenRepository) gin.HandlerFunc {
+// AuthMiddleware verifies the signed access token in-process, rejects tokens
+// whose session has been signed out, and protects the routes.
+func AuthMiddleware(tokenManager *auth.TokenManager, revocations *auth.RevocationList) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the token from the Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			log.Println("Missing Authorization header")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization token required"})
+			logger.FromContext(c.Request.Context()).Warn("Missing Authorization header")
+			c.Error(apperror.NewUnauthorized("Authorization token required"))
 			c.Abort()
 			return
 		}
@@ -25,33 +26,61 @@ func AuthMiddleware(tokenRepo repository.TokenRepository) gin.HandlerFunc {
 		// The token is usually in the format "Bearer <token>"
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			log.Println("Invalid Authorization format")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization format must be Bearer <token>"})
+			logger.FromContext(c.Request.Context()).Warn("Invalid Authorization format")
+			c.Error(apperror.NewUnauthorized("Authorization format must be Bearer <token>"))
 			c.Abort()
 			return
 		}
 
 		tokenString := parts[1]
 
-		// Fetch the token from the database
-		token, err := tokenRepo.FindByToken(tokenString)
+		// Verify the signature, issuer and expiry of the token
+		claims, err := tokenManager.Verify(tokenString)
 		if err != nil {
-			log.Printf("Token lookup failed: %v", err)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			logger.FromContext(c.Request.Context()).Info("Token verification failed", "error", err)
+			c.Error(apperror.NewUnauthorized("Invalid or expired token"))
 			c.Abort()
 			return
 		}
 
-		// Check if the token has expired
-		if token.ExpiresAt.Before(time.Now()) {
-			log.Printf("Token expired at: %v", token.ExpiresAt)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token expired"})
+		userID, err := claims.UserID()
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Warn("Invalid token subject", "error", err)
+			c.Error(apperror.NewUnauthorized("Invalid or expired token"))
+			c.Abort()
+			return
+		}
+
+		sessionID, err := claims.Session()
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Warn("Invalid token session", "error", err)
+			c.Error(apperror.NewUnauthorized("Invalid or expired token"))
 			c.Abort()
 			return
 		}
 
-		// If the token is valid, set the user ID in the request context
-		c.Set("userID", token.UserID)
+		// Check that the session has not been signed out
+		revoked, err := revocations.IsRevoked(c.Request.Context(), sessionID)
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Error("Session lookup failed", "error", err)
+			c.Error(fmt.Errorf("could not verify session: %w", err))
+			c.Abort()
+			return
+		}
+		if revoked {
+			logger.FromContext(c.Request.Context()).Info("Token used after session was revoked", "session_id", sessionID)
+			c.Error(apperror.NewUnauthorized("Token has been revoked"))
+			c.Abort()
+			return
+		}
+
+		// If the token is valid, set the user and session IDs in the request context
+		c.Set("userID", userID)
+		c.Set("sessionID", sessionID)
+
+		// Tag every later log line of this request with the user
+		ctx := c.Request.Context()
+		c.Request = c.Request.WithContext(logger.WithContext(ctx, logger.FromContext(ctx).With("user_id", userID)))
 
 		// Proceed to the next handler in the chain
 		c.Next()
@@ -0,0 +1,145 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/i18n"
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. Code is an extension member
+// holding the apperror kind so clients need not parse the detail text.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// Errors lists each invalid field of a 422 response
+	Errors []apperror.FieldError `json:"errors,omitempty"`
+}
+
+// kindStatus maps each kind of domain error to its HTTP status
+var kindStatus = map[apperror.Kind]int{
+	apperror.NotFound:        http.StatusNotFound,
+	apperror.Conflict:        http.StatusConflict,
+	apperror.Validation:      http.StatusUnprocessableEntity,
+	apperror.Forbidden:       http.StatusForbidden,
+	apperror.Unauthorized:    http.StatusUnauthorized,
+	apperror.TooManyRequests: http.StatusTooManyRequests,
+	apperror.BadRequest:      http.StatusBadRequest,
+}
+
+// ErrorMiddleware turns the last error a handler attached with c.Error into
+// a problem+json response. Domain errors get the status of their kind and
+// their message as the detail, and validation errors list their invalid
+// fields; anything else is a 500 whose detail is kept out of the response
+// and left to the request log. Messages are written in the language the
+// client asked for with Accept-Language when there is a translation.
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+
+		// Sign-in lockouts tell the client when to come back
+		var retry interface{ RetryAfter() time.Duration }
+		if errors.As(err, &retry) {
+			seconds := int(math.Ceil(retry.RetryAfter().Seconds()))
+			c.Header("Retry-After", strconv.Itoa(max(seconds, 1)))
+		}
+
+		kind := apperror.KindOf(err)
+		status, ok := kindStatus[kind]
+		switch {
+		case ok:
+			detail, params := domainDetail(err, kind)
+			writeProblem(c, status, kind.String(), detail, params, apperror.FieldsOf(err)...)
+		case errors.Is(err, context.DeadlineExceeded):
+			writeProblem(c, http.StatusGatewayTimeout, "timeout", "Request timed out", nil)
+		default:
+			writeProblem(c, http.StatusInternalServerError, kind.String(), "An unexpected error occurred", nil)
+		}
+	}
+}
+
+// domainDetail picks the message of err's domain error and its params when
+// the message is in the catalog, and the generic detail of kind otherwise, so
+// text that cannot be translated never reaches the client
+func domainDetail(err error, kind apperror.Kind) (string, []string) {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		if _, ok := i18n.Translate(i18n.Fallback(), appErr.Message, appErr.Params...); ok {
+			return appErr.Message, appErr.Params
+		}
+	}
+	return "detail." + kind.String(), nil
+}
+
+// writeProblem aborts the request with a problem details body, translating
+// its title, detail and field messages for the client. Params fill the
+// placeholders of the detail.
+func writeProblem(c *gin.Context, status int, code, detail string, params []string, fields ...apperror.FieldError) {
+	trans := i18n.Negotiate(c.GetHeader("Accept-Language"))
+
+	title, ok := i18n.Translate(trans, "status."+strconv.Itoa(status))
+	if !ok {
+		title = http.StatusText(status)
+	}
+	if translated, ok := i18n.Translate(trans, detail, params...); ok {
+		detail = translated
+	}
+
+	localized := make([]apperror.FieldError, len(fields))
+	for i, field := range fields {
+		localized[i] = localizeField(trans, field)
+	}
+
+	c.Header("Content-Type", ProblemContentType)
+	c.Header("Content-Language", trans.Locale())
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	c.AbortWithStatusJSON(status, Problem{
+		Type:      "about:blank",
+		Title:     title,
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		Code:      code,
+		RequestID: c.GetString("requestID"),
+		Errors:    localized,
+	})
+}
+
+// localizeField renders a field error's message from its code and params.
+// Params that name a kind of value, such as "number", are translated too.
+func localizeField(trans ut.Translator, field apperror.FieldError) apperror.FieldError {
+	params := []string{field.Field}
+	for _, param := range field.Params {
+		if translated, ok := i18n.Translate(trans, "param."+param); ok {
+			param = translated
+		}
+		params = append(params, param)
+	}
+
+	if message, ok := i18n.Translate(trans, "field."+field.Code, params...); ok {
+		field.Message = message
+	} else if field.Message == "" {
+		field.Message, _ = i18n.Translate(trans, "field.invalid", field.Field)
+	}
+	return field
+}
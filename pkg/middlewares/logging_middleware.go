@@ -1 +1,80 @@
 package middlewares
+
+import (
+	"log/slog"
+	"time"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+)
+
+// RequestIDHeader carries the ID that ties a request to its log lines
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client supplied request IDs so they cannot bloat the logs
+const maxRequestIDLength = 128
+
+// LoggingMiddleware assigns each request an ID, taking the caller's
+// X-Request-ID when it is usable, stores a logger tagged with that ID in the
+// request context, and writes one structured line when the request finishes.
+func LoggingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			id, err := uuid.NewV4()
+			if err == nil {
+				requestID = id.String()
+			}
+		}
+		c.Header(RequestIDHeader, requestID)
+		c.Set("requestID", requestID)
+
+		requestLogger := slog.Default().With("request_id", requestID)
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context(), requestLogger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", c.Request.URL.Path,
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"bytes", max(c.Writer.Size(), 0),
+			"client_ip", c.ClientIP(),
+		}
+		if userID, exists := c.Get("userID"); exists {
+			attrs = append(attrs, "user_id", userID)
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		requestLogger.Log(c.Request.Context(), level, "Request completed", attrs...)
+	}
+}
+
+// validRequestID accepts short IDs made of printable ASCII
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"fmt"
+
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/apperror"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/internal/repository"
+	"example.com/EVENT-MANAGEMENT-SYSTEM/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+)
+
+// PermissionMiddleware loads the authenticated user's effective permissions into the request context.
+// It must run after AuthMiddleware.
+func PermissionMiddleware(permissionRepo repository.PermissionRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.Error(apperror.NewUnauthorized("Authentication required"))
+			c.Abort()
+			return
+		}
+
+		names, err := permissionRepo.ListByUser(c.Request.Context(), userID.(uuid.UUID))
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Error("Permission lookup failed", "error", err)
+			c.Error(fmt.Errorf("could not load permissions: %w", err))
+			c.Abort()
+			return
+		}
+
+		permissions := make(map[string]bool, len(names))
+		for _, name := range names {
+			permissions[name] = true
+		}
+
+		c.Set("permissions", permissions)
+		c.Next()
+	}
+}
+
+// HasPermission reports whether the current request's user holds the permission.
+func HasPermission(c *gin.Context, permission string) bool {
+	permissions, ok := c.Get("permissions")
+	if !ok {
+		return false
+	}
+	return permissions.(map[string]bool)[permission]
+}
+
+// RequirePermission rejects requests whose user lacks the permission.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, permission) {
+			logger.FromContext(c.Request.Context()).Warn("Missing permission", "permission", permission)
+			c.Error(apperror.NewForbidden("You do not have permission to perform this action"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSelfOrPermission lets users act on their own record (the ":id" URL parameter)
+// and otherwise requires the permission.
+func RequireSelfOrPermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userID, exists := c.Get("userID"); exists && userID.(uuid.UUID).String() == c.Param("id") {
+			c.Next()
+			return
+		}
+		RequirePermission(permission)(c)
+	}
+}
@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutMiddleware gives each request a deadline. Services and repositories
+// receive it through the request context, so database calls and outgoing mail
+// are cancelled once it passes. A handler that ran out of time without
+// writing a response gets a 504.
+func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			writeProblem(c, http.StatusGatewayTimeout, "timeout", "Request timed out", nil)
+		}
+	}
+}
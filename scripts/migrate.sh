@@ -0,0 +1,10 @@
+#!/bin/sh
+# Manage the database schema, e.g.
+#   scripts/migrate.sh up
+#   scripts/migrate.sh down 1
+#   scripts/migrate.sh status
+#   scripts/migrate.sh create add_event_index
+#   scripts/migrate.sh hash-tokens
+set -e
+cd "$(dirname "$0")/.."
+exec go run ./cmd/server migrate "$@"